@@ -0,0 +1,89 @@
+package karma
+
+import (
+	"sync"
+)
+
+// OverflowKey is a context key which is used to store number of errors
+// dropped by BoundedAggregator.
+const OverflowKey = "_overflow"
+
+// BoundedAggregator collects errors up to specified limit, errors added
+// after limit is reached are only counted. It's safe for concurrent use.
+type BoundedAggregator struct {
+	mutex     sync.Mutex
+	maxErrors int
+	errors    ErrorList
+	overflow  int
+}
+
+// NewBoundedAggregator creates aggregator, which stores at most maxErrors
+// errors.
+func NewBoundedAggregator(maxErrors int) *BoundedAggregator {
+	return &BoundedAggregator{
+		maxErrors: maxErrors,
+	}
+}
+
+// Add stores given error or increments overflow counter if limit is
+// reached. Nil error is ignored.
+func (aggregator *BoundedAggregator) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	if len(aggregator.errors) >= aggregator.maxErrors {
+		aggregator.overflow++
+		return
+	}
+
+	aggregator.errors = aggregator.errors.Add(err)
+}
+
+// Overflow returns number of errors dropped because of limit.
+func (aggregator *BoundedAggregator) Overflow() int {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	return aggregator.overflow
+}
+
+// Err returns nil if no errors were added, single error as is or
+// hierarchical message "N errors" with all stored errors as reasons, where N
+// is total number of added errors. Number of dropped errors is added to
+// context with OverflowKey.
+func (aggregator *BoundedAggregator) Err() error {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	if len(aggregator.errors) == 0 && aggregator.overflow == 0 {
+		return nil
+	}
+
+	if len(aggregator.errors) == 1 && aggregator.overflow == 0 {
+		return aggregator.errors[0]
+	}
+
+	reasons := make([]Reason, len(aggregator.errors))
+	for index, err := range aggregator.errors {
+		reasons[index] = err
+	}
+
+	total := len(aggregator.errors) + aggregator.overflow
+
+	message := "%d errors"
+	if total == 1 {
+		message = "%d error"
+	}
+
+	karma := Format(reasons, message, total)
+
+	if aggregator.overflow > 0 {
+		karma = karma.Annotate(OverflowKey, aggregator.overflow)
+	}
+
+	return karma
+}
@@ -0,0 +1,112 @@
+package karma
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundedAggregator_CountsOverflow(t *testing.T) {
+	test := assert.New(t)
+
+	aggregator := NewBoundedAggregator(2)
+
+	test.NoError(aggregator.Err())
+
+	first := errors.New("first")
+	aggregator.Add(first)
+	aggregator.Add(nil)
+
+	test.Equal(first, aggregator.Err())
+
+	aggregator.Add(errors.New("second"))
+	aggregator.Add(errors.New("third"))
+	aggregator.Add(errors.New("fourth"))
+
+	test.Equal(2, aggregator.Overflow())
+
+	err := aggregator.Err()
+
+	test.Equal(
+		[]Reason{first, errors.New("second")},
+		GetReasons(err),
+	)
+
+	overflow, ok := GetContextValueTop(err, OverflowKey)
+	test.True(ok)
+	test.Equal(2, overflow)
+	test.True(errors.Is(err, first))
+
+	test.EqualError(
+		err,
+		output(
+			"4 errors",
+			"├─ first",
+			"├─ second",
+			"└─ _overflow: 2",
+		),
+	)
+}
+
+func TestBoundedAggregator_RendersOverflowWithoutStoredErrors(t *testing.T) {
+	test := assert.New(t)
+
+	aggregator := NewBoundedAggregator(0)
+	aggregator.Add(errors.New("first"))
+
+	test.EqualError(
+		aggregator.Err(),
+		output(
+			"1 error",
+			"└─ _overflow: 1",
+		),
+	)
+}
+
+func TestBoundedAggregator_OmitsOverflowWhenNotReached(t *testing.T) {
+	test := assert.New(t)
+
+	aggregator := NewBoundedAggregator(3)
+	aggregator.Add(errors.New("first"))
+	aggregator.Add(errors.New("second"))
+
+	test.Zero(aggregator.Overflow())
+
+	err := aggregator.Err()
+
+	_, ok := GetContextValueTop(err, OverflowKey)
+	test.False(ok)
+
+	test.EqualError(
+		err,
+		output(
+			"2 errors",
+			"├─ first",
+			"└─ second",
+		),
+	)
+}
+
+func TestBoundedAggregator_IsSafeForConcurrentUse(t *testing.T) {
+	test := assert.New(t)
+
+	aggregator := NewBoundedAggregator(10)
+
+	var group sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func(i int) {
+			defer group.Done()
+
+			aggregator.Add(fmt.Errorf("error %d", i))
+		}(i)
+	}
+
+	group.Wait()
+
+	test.Len(GetReasons(aggregator.Err()), 10)
+	test.Equal(90, aggregator.Overflow())
+}
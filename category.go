@@ -0,0 +1,63 @@
+package karma
+
+// Category represents semantic category of error, which can be used to
+// decide how error should be handled, e.g. whether operation can be retried.
+type Category string
+
+const (
+	// CategoryTransient means that error is temporary and operation can be
+	// retried.
+	CategoryTransient Category = "transient"
+
+	// CategoryPermanent means that operation will fail again if retried.
+	CategoryPermanent Category = "permanent"
+
+	// CategoryUserError means that error is caused by invalid user input.
+	CategoryUserError Category = "user_error"
+
+	// CategorySystemError means that error is caused by failure of system or
+	// its dependencies.
+	CategorySystemError Category = "system_error"
+)
+
+// WithCategory returns copy of given message with specified category.
+func WithCategory(err Karma, category Category) Karma {
+	err.Category = category
+
+	return err
+}
+
+// GetCategory returns category of top-level message. False is returned if
+// error is not hierarchical or category is not set.
+func GetCategory(err error) (Category, bool) {
+	karma, ok := getKarma(err)
+	if !ok || karma.Category == "" {
+		return "", false
+	}
+
+	return karma.Category, true
+}
+
+// GetEffectiveCategory returns category of the deepest level of the
+// hierarchy, which has category set. If there are several such levels with
+// the same depth, the first one in depth-first order is used.
+func GetEffectiveCategory(err error) (Category, bool) {
+	var (
+		result  Category
+		deepest = -1
+	)
+
+	Walk(err, func(depth int, reason Reason, _ *Context) {
+		karma, ok := getKarma(reason)
+		if !ok || karma.Category == "" {
+			return
+		}
+
+		if depth > deepest {
+			result = karma.Category
+			deepest = depth
+		}
+	})
+
+	return result, deepest >= 0
+}
@@ -0,0 +1,87 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCategory_SetsCategory(t *testing.T) {
+	test := assert.New(t)
+
+	err := WithCategory(
+		Format(errors.New("timeout"), "unable to connect"),
+		CategoryTransient,
+	)
+
+	category, ok := GetCategory(err)
+	test.True(ok)
+	test.Equal(CategoryTransient, category)
+
+	_, ok = GetCategory(Format(err, "unable to fetch"))
+	test.False(ok)
+
+	_, ok = GetCategory(errors.New("timeout"))
+	test.False(ok)
+}
+
+func TestGetEffectiveCategory_ReturnsDeepestCategory(t *testing.T) {
+	test := assert.New(t)
+
+	err := WithCategory(
+		Format(
+			Push(
+				"unable to fetch",
+				Format(
+					WithCategory(
+						Format(errors.New("timeout"), "unable to dial"),
+						CategoryTransient,
+					),
+					"unable to connect",
+				),
+				WithCategory(
+					Format(errors.New("denied"), "unable to auth"),
+					CategoryUserError,
+				),
+			),
+			"unable to sync",
+		),
+		CategorySystemError,
+	)
+
+	category, ok := GetEffectiveCategory(err)
+	test.True(ok)
+	test.Equal(CategoryTransient, category)
+
+	category, ok = GetEffectiveCategory(
+		WithCategory(Format(nil, "unable to sync"), CategoryPermanent),
+	)
+	test.True(ok)
+	test.Equal(CategoryPermanent, category)
+
+	_, ok = GetEffectiveCategory(Format(errors.New("timeout"), "unable to sync"))
+	test.False(ok)
+}
+
+func TestWithCategory_IsPreservedInJSON(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		WithCategory(Format(errors.New("timeout"), "unable to dial"), CategoryTransient),
+		"unable to connect",
+	)
+
+	test.Equal(
+		`{"reason":{"reason":"timeout","message":"unable to dial",`+
+			`"category":"transient"},"message":"unable to connect"}`,
+		JSON(err),
+	)
+
+	restored, unmarshalErr := NewFromJSONString(JSON(err))
+	test.NoError(unmarshalErr)
+
+	category, ok := GetEffectiveCategory(restored)
+	test.True(ok)
+	test.Equal(CategoryTransient, category)
+}
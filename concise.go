@@ -0,0 +1,43 @@
+package karma
+
+// Concise returns hierarchical string representation of given error without
+// context key-value pairs at every level of hierarchy.
+func Concise(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return renderReason(withoutContext(err))
+}
+
+func withoutContext(reason Reason) Reason {
+	karma, ok := getKarma(reason)
+	if !ok {
+		return reason
+	}
+
+	reasons := karma.GetReasons()
+
+	if karma.Message == "" && len(reasons) == 1 {
+		return withoutContext(reasons[0])
+	}
+
+	result := Karma{
+		Message: karma.Message,
+	}
+
+	switch len(reasons) {
+	case 0:
+	case 1:
+		result.Reason = withoutContext(reasons[0])
+	default:
+		stripped := make([]Reason, len(reasons))
+		for index, nested := range reasons {
+			stripped[index] = withoutContext(nested)
+		}
+
+		result.Reason = stripped
+	}
+
+	return result
+}
@@ -0,0 +1,41 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcise_RendersHierarchyWithoutContext(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		[]Reason{
+			Describe("port", 5432).Format(
+				Describe("attempt", 3).Reason(errors.New("connection refused")),
+				"dial failed",
+			),
+			errors.New("timeout"),
+		},
+		"unable to connect",
+	)
+
+	test.Equal(
+		output(
+			"unable to connect",
+			"├─ dial failed",
+			"│  └─ connection refused",
+			"│",
+			"└─ timeout",
+		),
+		Concise(err),
+	)
+}
+
+func TestConcise_RendersNonHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal("failure", Concise(errors.New("failure")))
+	test.Equal("", Concise(nil))
+}
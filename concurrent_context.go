@@ -0,0 +1,63 @@
+package karma
+
+import (
+	"sync"
+)
+
+// ConcurrentContext is a context list, which can be built by several
+// goroutines concurrently:
+//
+//	context := &karma.ConcurrentContext{}
+//
+//	for _, host := range hosts {
+//		host := host
+//		context.Go(func() {
+//			context.Describe(host, ping(host))
+//		})
+//	}
+//
+//	return context.Build().Format(err, "unable to ping hosts")
+//
+// Zero value is ready to use.
+type ConcurrentContext struct {
+	mutex   sync.Mutex
+	group   sync.WaitGroup
+	context *Context
+}
+
+// Describe adds new key-value pair to context list. It's safe to call it
+// from several goroutines.
+func (context *ConcurrentContext) Describe(key string, value interface{}) {
+	context.mutex.Lock()
+	defer context.mutex.Unlock()
+
+	context.context = context.context.Describe(key, value)
+}
+
+// Go runs given function in new goroutine, Wait() blocks until function
+// returns.
+func (context *ConcurrentContext) Go(fn func()) {
+	context.group.Add(1)
+
+	go func() {
+		defer context.group.Done()
+
+		fn()
+	}()
+}
+
+// Wait blocks until all goroutines started by Go() are done.
+func (context *ConcurrentContext) Wait() {
+	context.group.Wait()
+}
+
+// Build waits for all goroutines and returns built context list. Pairs are
+// ordered in the same way as they were added.
+func (context *ConcurrentContext) Build() *Context {
+	context.Wait()
+
+	context.mutex.Lock()
+	defer context.mutex.Unlock()
+
+	return context.context
+}
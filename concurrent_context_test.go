@@ -0,0 +1,43 @@
+package karma
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentContext_CollectsPairsFromGoroutines(t *testing.T) {
+	test := assert.New(t)
+
+	context := &ConcurrentContext{}
+
+	for i := 0; i < 100; i++ {
+		i := i
+		context.Go(func() {
+			context.Describe(fmt.Sprintf("key%d", i), i)
+		})
+	}
+
+	pairs := map[string]interface{}{}
+	context.Build().Walk(func(key string, value interface{}) {
+		pairs[key] = value
+	})
+
+	test.Len(pairs, 100)
+	for i := 0; i < 100; i++ {
+		test.Equal(i, pairs[fmt.Sprintf("key%d", i)])
+	}
+}
+
+func TestConcurrentContext_BuildsEmptyContext(t *testing.T) {
+	test := assert.New(t)
+
+	context := &ConcurrentContext{}
+
+	test.Nil(context.Build())
+	test.EqualError(
+		context.Build().Format(nil, "unable to ping"),
+		"unable to ping",
+	)
+}
@@ -1,14 +1,18 @@
 package karma
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 )
 
 // Context is a element of key-value linked list of message contexts.
 type Context struct {
 	KeyValue
 	Next *Context
+
+	lazy *lazyContext
 }
 
 type KeyValue struct {
@@ -22,10 +26,10 @@ func (context *Context) Describe(
 	key string,
 	value interface{},
 ) *Context {
-	if context == nil {
+	if context == nil || context.isEmpty() {
 		return &Context{
 			KeyValue: KeyValue{
-				Key:   key,
+				Key:   internKey(key),
 				Value: value,
 			},
 		}
@@ -43,7 +47,7 @@ func (context *Context) Describe(
 
 	pointer.Next = &Context{
 		KeyValue: KeyValue{
-			Key:   key,
+			Key:   internKey(key),
 			Value: value,
 		},
 	}
@@ -51,6 +55,40 @@ func (context *Context) Describe(
 	return &head
 }
 
+func (context *Context) isEmpty() bool {
+	return context.Next == nil && context.Key == "" && context.Value == nil &&
+		context.lazy == nil
+}
+
+// Prepend adds new key-value context pair to the head of current context list
+// and returns new context list. Current context list is not changed.
+func (context *Context) Prepend(
+	key string,
+	value interface{},
+) *Context {
+	return &Context{
+		KeyValue: KeyValue{
+			Key:   internKey(key),
+			Value: value,
+		},
+		Next: context,
+	}
+}
+
+// DescribeJSON adds new key-value context pair with raw JSON value to current
+// context list. See DescribeJSON() for details.
+func (context *Context) DescribeJSON(key string, rawJSON []byte) *Context {
+	return context.Describe(key, jsonValue(rawJSON))
+}
+
+func appendContext(context *Context, other *Context) *Context {
+	other.Walk(func(key string, value interface{}) {
+		context = context.Describe(key, value)
+	})
+
+	return context
+}
+
 // Format produces context-rich hierarchical message, which will include all
 // previously declared context key-value pairs.
 func (context *Context) Format(
@@ -58,11 +96,7 @@ func (context *Context) Format(
 	message string,
 	args ...interface{},
 ) Karma {
-	return Karma{
-		Message: fmt.Sprintf(message, args...),
-		Reason:  reason,
-		Context: context,
-	}
+	return format(context, reason, message, args)
 }
 
 // Reason adds current context to the specified message. If message is not
@@ -82,6 +116,14 @@ func (context *Context) Reason(reason Reason) Karma {
 	}
 }
 
+// AppendTo returns copy of given message with current context pairs appended
+// to its context. No new nesting level is created.
+func (context *Context) AppendTo(karma Karma) Karma {
+	karma.Context = appendContext(karma.Context, context)
+
+	return karma
+}
+
 // Walk iterates over all key-value context pairs and calls specified
 // callback for each.
 func (context *Context) Walk(callback func(string, interface{})) {
@@ -89,7 +131,9 @@ func (context *Context) Walk(callback func(string, interface{})) {
 		return
 	}
 
-	if context.Key != "" || context.Value != nil {
+	if context.lazy != nil {
+		context.lazy.get().Walk(callback)
+	} else if context.Key != "" || context.Value != nil {
 		callback(context.Key, context.Value)
 	}
 
@@ -98,6 +142,136 @@ func (context *Context) Walk(callback func(string, interface{})) {
 	}
 }
 
+// lookup returns value of the last pair with specified key. Values marked as
+// PII are returned as is, they are redacted only when rendered.
+func (context *Context) lookup(key string) (interface{}, bool) {
+	var (
+		result interface{}
+		found  bool
+	)
+
+	context.Walk(func(name string, value interface{}) {
+		if name == key {
+			result = unwrapPII(value)
+			found = true
+		}
+	})
+
+	return result, found
+}
+
+// without returns copy of context list without pairs with specified key.
+func (context *Context) without(key string) *Context {
+	var result *Context
+
+	context.Walk(func(name string, value interface{}) {
+		if name != key {
+			result = result.Describe(name, value)
+		}
+	})
+
+	return result
+}
+
+// findContextValue searches for context value with specified key in the
+// whole hierarchy, starting from top-level.
+func findContextValue(reason Reason, key string) (interface{}, bool) {
+	var (
+		result interface{}
+		found  bool
+	)
+
+	walk(reason, 0, func(_ int, _ Reason, context *Context) bool {
+		result, found = context.lookup(key)
+
+		return !found
+	})
+
+	return result, found
+}
+
+// GetContextValueTop returns value of specified key from top-level context of
+// given error. Contexts of nested levels are not searched, see
+// GetContextValueDeep().
+//
+// It is not named GetContextValue, because that name is already taken by
+// generic lookup by ContextKey, and Go doesn't allow overloading: use
+// GetContextValue() with ContextKey for typed keys.
+func GetContextValueTop(err error, key string) (interface{}, bool) {
+	karma, ok := getKarma(err)
+	if !ok {
+		return nil, false
+	}
+
+	return karma.Context.lookup(key)
+}
+
+// GetContextValueDeep returns value of specified key from context of any
+// level of the hierarchy. Levels are searched in depth-first order, starting
+// from top-level, and first found value is returned.
+func GetContextValueDeep(err error, key string) (interface{}, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	return findContextValue(err, key)
+}
+
+// GetContextValueAs is the same as GetContextValueTop(), but also asserts
+// type of found value. False is returned if value has different type.
+func GetContextValueAs[T any](err error, key string) (T, bool) {
+	value, ok := GetContextValueTop(err, key)
+	if !ok {
+		var zero T
+
+		return zero, false
+	}
+
+	typed, ok := value.(T)
+
+	return typed, ok
+}
+
+// contextInt converts integer context value to int64, values restored
+// from JSON are handled as well.
+func contextInt(value interface{}) (int64, bool) {
+	switch value := value.(type) {
+	case int:
+		return int64(value), true
+	case int8:
+		return int64(value), true
+	case int16:
+		return int64(value), true
+	case int32:
+		return int64(value), true
+	case int64:
+		return value, true
+	case uint:
+		return int64(value), true
+	case uint8:
+		return int64(value), true
+	case uint16:
+		return int64(value), true
+	case uint32:
+		return int64(value), true
+	case uint64:
+		return int64(value), true
+	case float32:
+		return contextInt(float64(value))
+	case float64:
+		if value != math.Trunc(value) {
+			return 0, false
+		}
+
+		return int64(value), true
+	case json.Number:
+		result, err := value.Int64()
+		return result, err == nil
+	default:
+		return 0, false
+	}
+}
+
 // GetKeyValuePairs returns slice of key-value context pairs, which will
 // be always even, each even index is key and each odd index is value.
 func (context *Context) GetKeyValuePairs() []interface{} {
@@ -121,7 +295,25 @@ func (context *Context) GetKeyValues() []KeyValue {
 	return result
 }
 
+const (
+	// ContextJSONFormatArray makes MarshalJSON() produce array of key-value
+	// pairs: [{"key":"host","value":"example.com"}].
+	ContextJSONFormatArray = "array"
+
+	// ContextJSONFormatObject makes MarshalJSON() produce JSON object:
+	// {"host":"example.com"}. See MarshalJSONObject() for details.
+	ContextJSONFormatObject = "object"
+)
+
+// ContextJSONFormat controls JSON representation of context lists, produced
+// by MarshalJSON(). Both formats can be unmarshaled.
+var ContextJSONFormat = ContextJSONFormatArray
+
 func (context *Context) MarshalJSON() ([]byte, error) {
+	if ContextJSONFormat == ContextJSONFormatObject {
+		return context.MarshalJSONObject()
+	}
+
 	linear := []interface{}{}
 
 	context.Walk(func(key string, value interface{}) {
@@ -134,15 +326,81 @@ func (context *Context) MarshalJSON() ([]byte, error) {
 	return json.Marshal(linear)
 }
 
+// MarshalJSONObject returns JSON object representation of context list. Keys
+// are ordered in the same way as they were added and only the last value is
+// preserved for duplicated keys.
+func (context *Context) MarshalJSONObject() ([]byte, error) {
+	var (
+		keys   = []string{}
+		values = map[string]interface{}{}
+	)
+
+	context.Walk(func(key string, value interface{}) {
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		values[key] = value
+	})
+
+	buffer := bytes.NewBufferString("{")
+
+	for i, key := range keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// JSON returns JSON object representation of context list, see
+// MarshalJSONObject().
+func (context *Context) JSON() ([]byte, error) {
+	return context.MarshalJSONObject()
+}
+
+// MustJSON is the same as JSON(), but returns string and panics if context
+// can't be marshaled.
+func (context *Context) MustJSON() string {
+	data, err := context.JSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(data)
+}
+
 func (context *Context) UnmarshalJSON(data []byte) error {
 	var container []KeyValue
 
-	err := json.Unmarshal(data, &container)
+	var err error
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		container, err = unmarshalJSONObject(trimmed)
+	} else {
+		err = json.Unmarshal(data, &container)
+	}
 	if err != nil {
 		return err
 	}
 
-	var result *Context
+	result := NewContext()
 
 	for _, item := range container {
 		result = result.Describe(item.Key, item.Value)
@@ -152,3 +410,44 @@ func (context *Context) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// unmarshalJSONObject decodes JSON object into key-value pairs preserving
+// order of keys.
+func unmarshalJSONObject(data []byte) ([]KeyValue, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	_, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := []KeyValue{}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected JSON object key: %v", token)
+		}
+
+		var value interface{}
+
+		err = decoder.Decode(&value)
+		if err != nil {
+			return nil, err
+		}
+
+		pairs = append(pairs, KeyValue{Key: key, Value: value})
+	}
+
+	_, err = decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,57 @@
+package karma
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+)
+
+const (
+	// DeadlineKey is a context key which is used to store deadline of
+	// context.Context, which was exceeded.
+	DeadlineKey = "_deadline"
+
+	// ElapsedKey is a context key which is used to store time elapsed since
+	// exceeded deadline of context.Context.
+	ElapsedKey = "_elapsed"
+
+	// NetworkTimeoutKey is a context key which is used to mark errors caused
+	// by network timeout.
+	NetworkTimeoutKey = "_network_timeout"
+)
+
+// FormatContextError creates new hierarchical message same as Format(), but
+// if deadline of ctx is exceeded, the deadline and time elapsed since it are
+// added to the context. If err is network error caused by timeout, it is
+// marked by "_network_timeout" context pair. Default context carried by ctx
+// is used, see WithDefaultContext().
+func FormatContextError(
+	ctx context.Context,
+	err error,
+	message string,
+	args ...interface{},
+) Karma {
+	var karmaCtx *Context
+
+	if ctx != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if deadline, ok := ctx.Deadline(); ok {
+			karmaCtx = karmaCtx.
+				Describe(DeadlineKey, deadline.Format(time.RFC3339Nano)).
+				Describe(ElapsedKey, time.Since(deadline).String())
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		karmaCtx = karmaCtx.Describe(NetworkTimeoutKey, true)
+	}
+
+	return formatWithDefaults(
+		karmaCtx,
+		err,
+		message,
+		args,
+		GetDefaultContext(ctx),
+	)
+}
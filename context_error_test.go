@@ -0,0 +1,71 @@
+package karma
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatContextError_AddsDeadlineInfo(t *testing.T) {
+	test := assert.New(t)
+
+	deadline := time.Now().Add(-time.Second)
+
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	err := FormatContextError(ctx, ctx.Err(), "unable to fetch %s", "user")
+
+	test.Equal("unable to fetch user", err.GetMessage())
+	test.True(errors.Is(err, context.DeadlineExceeded))
+
+	value, ok := err.GetContext().lookup(DeadlineKey)
+	test.True(ok)
+	test.Equal(deadline.Format(time.RFC3339Nano), value)
+
+	value, ok = err.GetContext().lookup(ElapsedKey)
+	test.True(ok)
+
+	elapsed, parseErr := time.ParseDuration(value.(string))
+	test.NoError(parseErr)
+	test.GreaterOrEqual(elapsed, time.Second)
+}
+
+func TestFormatContextError_MarksNetworkTimeout(t *testing.T) {
+	test := assert.New(t)
+
+	netErr := &net.DNSError{
+		Err:       "i/o timeout",
+		Name:      "example.com",
+		IsTimeout: true,
+	}
+
+	err := FormatContextError(
+		context.Background(),
+		Format(netErr, "unable to resolve"),
+		"unable to connect",
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ unable to resolve",
+			"│  └─ lookup example.com: i/o timeout",
+			"│",
+			"└─ _network_timeout: true",
+		),
+	)
+
+	test.EqualError(
+		FormatContextError(context.Background(), context.Canceled, "stopped"),
+		output(
+			"stopped",
+			"└─ context canceled",
+		),
+	)
+}
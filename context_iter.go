@@ -0,0 +1,32 @@
+//go:build go1.23
+
+package karma
+
+import "iter"
+
+// All returns iterator over all key-value pairs of context in order they
+// were added.
+func (context *Context) All() iter.Seq2[string, interface{}] {
+	return func(yield func(string, interface{}) bool) {
+		for node := context.resolve(); node != nil; node = node.Next {
+			if node.Key == "" && node.Value == nil {
+				continue
+			}
+
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns iterator over keys of context in order they were added.
+func (context *Context) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for key := range context.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
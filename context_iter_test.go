@@ -0,0 +1,58 @@
+//go:build go1.23
+
+package karma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_All_IteratesOverPairs(t *testing.T) {
+	test := assert.New(t)
+
+	context := Describe("host", "example.com").Describe("port", 80)
+
+	keys := []string{}
+	values := []interface{}{}
+	for key, value := range context.All() {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	test.Equal([]string{"host", "port"}, keys)
+	test.Equal([]interface{}{"example.com", 80}, values)
+}
+
+func TestContext_All_StopsOnBreak(t *testing.T) {
+	test := assert.New(t)
+
+	context := Describe("a", 1).Describe("b", 2).Describe("c", 3)
+
+	keys := []string{}
+	for key := range context.All() {
+		keys = append(keys, key)
+		if key == "b" {
+			break
+		}
+	}
+
+	test.Equal([]string{"a", "b"}, keys)
+}
+
+func TestContext_Keys_IteratesOverKeys(t *testing.T) {
+	test := assert.New(t)
+
+	var context *Context
+
+	for range context.Keys() {
+		test.Fail("nil context should not have keys")
+	}
+
+	keys := []string{}
+	for key := range Describe("a", 1).Describe("b", 2).Keys() {
+		keys = append(keys, key)
+	}
+
+	test.Equal([]string{"a", "b"}, keys)
+}
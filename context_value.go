@@ -0,0 +1,123 @@
+package karma
+
+import (
+	"context"
+	"sync"
+)
+
+type contextKey struct {
+	key      interface{}
+	karmaKey string
+}
+
+var contextKeys = struct {
+	sync.RWMutex
+	list []contextKey
+}{}
+
+// RegisterContextKey registers key of context.Context value, which will be
+// described with karmaKey by DescribeAllRegisteredContextValues(). Keys are
+// described in the order of registration.
+func RegisterContextKey(key interface{}, karmaKey string) {
+	contextKeys.Lock()
+	defer contextKeys.Unlock()
+
+	for index, registered := range contextKeys.list {
+		if registered.key == key {
+			contextKeys.list[index].karmaKey = karmaKey
+			return
+		}
+	}
+
+	contextKeys.list = append(contextKeys.list, contextKey{key, karmaKey})
+}
+
+// UnregisterContextKey removes key registered by RegisterContextKey().
+func UnregisterContextKey(key interface{}) {
+	contextKeys.Lock()
+	defer contextKeys.Unlock()
+
+	for index, registered := range contextKeys.list {
+		if registered.key == key {
+			contextKeys.list = append(
+				contextKeys.list[:index:index],
+				contextKeys.list[index+1:]...,
+			)
+			return
+		}
+	}
+}
+
+// DescribeContextValue creates new context list with value stored in ctx by
+// specified key, described as karmaKey. If ctx has no such value, nil context
+// list is returned.
+func DescribeContextValue(
+	ctx context.Context,
+	key interface{},
+	karmaKey string,
+) *Context {
+	return describeContextValue(nil, ctx, key, karmaKey)
+}
+
+// DescribeAllRegisteredContextValues creates new context list with all values
+// stored in ctx by keys registered with RegisterContextKey().
+func DescribeAllRegisteredContextValues(ctx context.Context) *Context {
+	contextKeys.RLock()
+	defer contextKeys.RUnlock()
+
+	var result *Context
+	for _, registered := range contextKeys.list {
+		result = describeContextValue(
+			result,
+			ctx,
+			registered.key,
+			registered.karmaKey,
+		)
+	}
+
+	return result
+}
+
+// DescribeFromContext creates new context list with all values stored in ctx
+// by keys registered with RegisterContextKey(). It's the same as
+// DescribeAllRegisteredContextValues().
+func DescribeFromContext(ctx context.Context) *Context {
+	return DescribeAllRegisteredContextValues(ctx)
+}
+
+// FormatWithContext creates new hierarchical message same as Format(), but
+// values stored in ctx by keys registered with RegisterContextKey() are added
+// to its context. Default context carried by ctx, see WithDefaultContext(),
+// is used instead of one set by SetDefaultContext().
+func FormatWithContext(
+	ctx context.Context,
+	reason Reason,
+	message string,
+	args ...interface{},
+) Karma {
+	return formatWithDefaults(
+		DescribeFromContext(ctx),
+		reason,
+		message,
+		args,
+		GetDefaultContext(ctx),
+	)
+}
+
+func describeContextValue(
+	result *Context,
+	ctx context.Context,
+	key interface{},
+	karmaKey string,
+) *Context {
+	if ctx == nil {
+		return result
+	}
+
+	value := ctx.Value(key)
+	if value == nil {
+		return result
+	}
+
+	return result.Describe(karmaKey, value)
+}
@@ -0,0 +1,91 @@
+package karma
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRequestIDKey struct{}
+
+type testUserKey struct{}
+
+func TestDescribeContextValue_DescribesValue(t *testing.T) {
+	test := assert.New(t)
+
+	ctx := context.WithValue(context.Background(), testRequestIDKey{}, "abc")
+
+	test.EqualError(
+		DescribeContextValue(ctx, testRequestIDKey{}, "request_id").
+			Format(nil, "unable to handle request"),
+		output(
+			"unable to handle request",
+			"└─ request_id: abc",
+		),
+	)
+
+	test.Nil(DescribeContextValue(ctx, testUserKey{}, "user"))
+}
+
+func TestDescribeAllRegisteredContextValues_DescribesRegisteredKeys(t *testing.T) {
+	test := assert.New(t)
+
+	RegisterContextKey(testRequestIDKey{}, "request_id")
+	defer UnregisterContextKey(testRequestIDKey{})
+
+	RegisterContextKey(testUserKey{}, "user")
+	defer UnregisterContextKey(testUserKey{})
+
+	ctx := context.WithValue(context.Background(), testUserKey{}, "root")
+	ctx = context.WithValue(ctx, testRequestIDKey{}, "abc")
+
+	test.EqualError(
+		DescribeAllRegisteredContextValues(ctx).
+			Format(nil, "unable to handle request"),
+		output(
+			"unable to handle request",
+			"├─ request_id: abc",
+			"└─ user: root",
+		),
+	)
+
+	UnregisterContextKey(testUserKey{})
+
+	test.EqualError(
+		DescribeAllRegisteredContextValues(ctx).
+			Format(nil, "unable to handle request"),
+		output(
+			"unable to handle request",
+			"└─ request_id: abc",
+		),
+	)
+}
+
+func TestFormatWithContext_AddsRegisteredValues(t *testing.T) {
+	test := assert.New(t)
+
+	RegisterContextKey(testRequestIDKey{}, "request_id")
+	defer UnregisterContextKey(testRequestIDKey{})
+
+	ctx := context.WithValue(context.Background(), testRequestIDKey{}, "abc")
+	ctx = WithDefaultContext(ctx, Describe("service", "api"))
+
+	test.EqualError(
+		FormatWithContext(ctx, errors.New("timeout"), "unable to get %s", "user"),
+		output(
+			"unable to get user",
+			"├─ timeout",
+			"├─ request_id: abc",
+			"└─ service: api",
+		),
+	)
+
+	test.Equal(
+		[]interface{}{"request_id", "abc"},
+		DescribeFromContext(ctx).GetKeyValuePairs(),
+	)
+
+	test.Nil(DescribeFromContext(nil))
+}
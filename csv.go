@@ -0,0 +1,80 @@
+package karma
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ToCSV returns CSV row representation of given error with following
+// columns: timestamp, message, context_keys, context_values and depth.
+// Message is a colon-joined messages of all levels, context keys and values
+// of all levels are joined by semicolons, depth is the number of nesting
+// levels. Timestamp is taken from the context if it was added by
+// WithTimestamp(), otherwise current time is used. Empty string is returned
+// for nil error.
+func ToCSV(err error) string {
+	var buffer bytes.Buffer
+
+	writer := csv.NewWriter(&buffer)
+
+	writeErr := WriteCSV(writer, err)
+	if writeErr != nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// WriteCSV writes CSV row representation of given error to w and flushes it.
+// See ToCSV() for details.
+func WriteCSV(w *csv.Writer, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	writeErr := w.Write(csvRecord(err))
+	if writeErr != nil {
+		return writeErr
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+func csvRecord(err error) []string {
+	var (
+		timestamp = time.Now()
+		keys      []string
+		values    []string
+		depth     int
+	)
+
+	Walk(err, func(level int, _ Reason, context *Context) {
+		if level > depth {
+			depth = level
+		}
+
+		context.Walk(func(key string, value interface{}) {
+			if key == TimestampKey {
+				if value, ok := value.(time.Time); ok {
+					timestamp = value
+				}
+			}
+
+			keys = append(keys, key)
+			values = append(values, formatContextValue(key, value))
+		})
+	})
+
+	return []string{
+		timestamp.UTC().Format(time.RFC3339),
+		strings.Join(GetMessages(err), ": "),
+		strings.Join(keys, ";"),
+		strings.Join(values, ";"),
+		strconv.Itoa(depth),
+	}
+}
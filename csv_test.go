@@ -0,0 +1,57 @@
+package karma
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToCSV_ReturnsQuotedRow(t *testing.T) {
+	test := assert.New(t)
+
+	timestamp := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	err := Describe(TimestampKey, timestamp).
+		Describe("hosts", "a,b").
+		Format(
+			Describe("port", 80).Format(
+				errors.New("timeout"),
+				"unable to dial",
+			),
+			"unable to connect",
+		)
+
+	test.Equal(
+		`2020-01-02T03:04:05Z,unable to connect: unable to dial: timeout,`+
+			`_timestamp;hosts;port,"2020-01-02T03:04:05Z;a,b;80",2`,
+		ToCSV(err),
+	)
+
+	test.Equal("", ToCSV(nil))
+}
+
+func TestWriteCSV_WritesRowToWriter(t *testing.T) {
+	test := assert.New(t)
+
+	var buffer bytes.Buffer
+
+	writer := csv.NewWriter(&buffer)
+
+	test.NoError(WriteCSV(writer, errors.New("multi\nline")))
+	test.NoError(WriteCSV(writer, nil))
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	test.NoError(err)
+	test.Len(records, 1)
+
+	_, parseErr := time.Parse(time.RFC3339, records[0][0])
+	test.NoError(parseErr)
+	test.Equal([]string{"multi\nline", "", "", "0"}, records[0][1:])
+
+	test.False(strings.HasSuffix(ToCSV(errors.New("a")), "\n"))
+}
@@ -0,0 +1,51 @@
+package karma
+
+// DeduplicateReasons returns copy of given error without reasons, which
+// string representation matches one of reasons already seen during
+// depth-first traversal of the hierarchy. Error, which is not hierarchical
+// message, is returned as the only reason of new message.
+func DeduplicateReasons(err error) Karma {
+	if err == nil {
+		return Karma{}
+	}
+
+	karma, ok := getKarma(err)
+	if !ok {
+		return Karma{Reason: err}
+	}
+
+	return deduplicateReasons(*karma, map[string]struct{}{})
+}
+
+// FormatDeduped creates new hierarchical message same as Format(), but
+// duplicate reasons are removed. See DeduplicateReasons() for details.
+func FormatDeduped(
+	reason Reason,
+	message string,
+	args ...interface{},
+) Karma {
+	return DeduplicateReasons(Format(reason, message, args...))
+}
+
+func deduplicateReasons(karma Karma, seen map[string]struct{}) Karma {
+	var reasons []Reason
+
+	for _, reason := range karma.GetReasons() {
+		key := stringReason(reason)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		if nested, ok := getKarma(reason); ok {
+			reason = deduplicateReasons(*nested, seen)
+		}
+
+		reasons = append(reasons, reason)
+	}
+
+	karma.Reason = joinReasons(reasons)
+
+	return karma
+}
@@ -0,0 +1,78 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicateReasons_RemovesRepeatedReasons(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		[]Reason{
+			Format(errors.New("timeout"), "attempt failed"),
+			Format(errors.New("timeout"), "attempt failed"),
+			Format(
+				[]Reason{errors.New("timeout"), errors.New("refused")},
+				"last attempt failed",
+			),
+		},
+		"unable to connect",
+	)
+
+	test.EqualError(
+		DeduplicateReasons(err),
+		output(
+			"unable to connect",
+			"├─ attempt failed",
+			"│  └─ timeout",
+			"│",
+			"└─ last attempt failed",
+			"   └─ refused",
+		),
+	)
+}
+
+func TestFormatDeduped_DeduplicatesOnConstruction(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		FormatDeduped(
+			[]Reason{errors.New("timeout"), errors.New("timeout")},
+			"unable to connect to %s",
+			"example.com",
+		),
+		output(
+			"unable to connect to example.com",
+			"└─ timeout",
+		),
+	)
+}
+
+func TestDeduplicateReasons_HandlesNonHierarchicalErrors(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(Karma{}, DeduplicateReasons(nil))
+
+	plain := errors.New("plain")
+
+	err := DeduplicateReasons(plain)
+
+	test.EqualError(
+		err,
+		output(
+			"",
+			"└─ plain",
+		),
+	)
+	test.True(errors.Is(err, plain))
+	test.Equal("plain", err.GetMessage())
+
+	var custom customSimpleError
+	test.True(
+		errors.As(DeduplicateReasons(customSimpleError{"custom"}), &custom),
+	)
+	test.Equal("custom", custom.text)
+}
@@ -0,0 +1,72 @@
+package karma
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type defaultContextKey struct{}
+
+var defaultContext atomic.Pointer[Context]
+
+// SetDefaultContext sets context list, which will be appended to context of
+// every message created by Format() and Context.Format(). Pairs which keys
+// are already present in the call-site context or in the reason hierarchy
+// are not added, so default pairs are not repeated on every nesting level.
+func SetDefaultContext(context *Context) {
+	defaultContext.Store(context)
+}
+
+// ClearDefaultContext removes context list set by SetDefaultContext().
+func ClearDefaultContext() {
+	defaultContext.Store(nil)
+}
+
+// WithDefaultContext returns copy of ctx, which carries default context list
+// overriding one set by SetDefaultContext(). It is honored by functions
+// accepting context.Context.
+func WithDefaultContext(ctx context.Context, karmaCtx *Context) context.Context {
+	return context.WithValue(ctx, defaultContextKey{}, karmaCtx)
+}
+
+// GetDefaultContext returns default context list carried by ctx or set by
+// SetDefaultContext() if ctx has none. Ctx can be nil.
+func GetDefaultContext(ctx context.Context) *Context {
+	if ctx != nil {
+		if karmaCtx, ok := ctx.Value(defaultContextKey{}).(*Context); ok {
+			return karmaCtx
+		}
+	}
+
+	return defaultContext.Load()
+}
+
+func mergeDefaultContext(
+	context *Context,
+	reason Reason,
+	defaults *Context,
+) *Context {
+	// lazy pairs are not evaluated here, otherwise FormatLazy() would call
+	// its factory on every Format() when default context is set
+	defaults.Walk(func(key string, value interface{}) {
+		if _, ok := context.lookupEager(key); ok {
+			return
+		}
+
+		found := false
+
+		walk(reason, 0, func(_ int, _ Reason, context *Context) bool {
+			_, found = context.lookupEager(key)
+
+			return !found
+		})
+
+		if found {
+			return
+		}
+
+		context = context.Describe(key, value)
+	})
+
+	return context
+}
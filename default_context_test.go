@@ -0,0 +1,68 @@
+package karma
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDefaultContext_AppendsDefaultContext(t *testing.T) {
+	test := assert.New(t)
+
+	SetDefaultContext(Describe("hostname", "node-1").Describe("env", "prod"))
+	defer ClearDefaultContext()
+
+	test.EqualError(
+		Describe("env", "test").Format(io.EOF, "unable to read"),
+		output(
+			"unable to read",
+			"├─ EOF",
+			"├─ env: test",
+			"└─ hostname: node-1",
+		),
+	)
+}
+
+func TestSetDefaultContext_DoesNotRepeatDefaultContext(t *testing.T) {
+	test := assert.New(t)
+
+	SetDefaultContext(Describe("hostname", "node-1"))
+	defer ClearDefaultContext()
+
+	test.EqualError(
+		Format(Format(io.EOF, "unable to read"), "unable to load"),
+		output(
+			"unable to load",
+			"└─ unable to read",
+			"   ├─ EOF",
+			"   └─ hostname: node-1",
+		),
+	)
+}
+
+func TestClearDefaultContext_RemovesDefaultContext(t *testing.T) {
+	test := assert.New(t)
+
+	SetDefaultContext(Describe("hostname", "node-1"))
+	ClearDefaultContext()
+
+	test.EqualError(Format(nil, "unable to read"), "unable to read")
+}
+
+func TestWithDefaultContext_OverridesDefaultContext(t *testing.T) {
+	test := assert.New(t)
+
+	SetDefaultContext(Describe("hostname", "node-1"))
+	defer ClearDefaultContext()
+
+	ctx := WithDefaultContext(context.Background(), Describe("request", "abc"))
+
+	test.Equal(Describe("request", "abc"), GetDefaultContext(ctx))
+	test.Equal(
+		Describe("hostname", "node-1"),
+		GetDefaultContext(context.Background()),
+	)
+	test.Equal(Describe("hostname", "node-1"), GetDefaultContext(nil))
+}
@@ -0,0 +1,141 @@
+package karma
+
+import (
+	"strings"
+)
+
+// Diff returns human-readable difference between two errors. Both errors are
+// compared as trees of messages and context pairs: lines of removed nodes are
+// prefixed with "-", lines of added nodes are prefixed with "+" and unchanged
+// nodes are kept as context, prefixed with space. Empty string is returned if
+// there is no difference.
+func Diff(a, b error) string {
+	differ := &differ{}
+
+	switch {
+	case a == nil && b == nil:
+		return ""
+	case a == nil:
+		differ.write('+', newDiffNode(b), 0)
+	case b == nil:
+		differ.write('-', newDiffNode(a), 0)
+	default:
+		differ.diff(newDiffNode(a), newDiffNode(b), 0)
+	}
+
+	if !differ.changed {
+		return ""
+	}
+
+	return strings.Join(differ.lines, "\n")
+}
+
+type diffNode struct {
+	label    string
+	children []diffNode
+}
+
+func newDiffNode(reason Reason) diffNode {
+	karma, ok := getKarma(reason)
+	if !ok {
+		return diffNode{label: stringReason(reason)}
+	}
+
+	node := diffNode{label: karma.GetMessage()}
+
+	if karma.Message != "" {
+		for _, nested := range karma.GetReasons() {
+			node.children = append(node.children, newDiffNode(nested))
+		}
+	}
+
+	karma.Context.Walk(func(key string, value interface{}) {
+		node.children = append(node.children, diffNode{
+			label: key + ": " + formatContextValue(key, value),
+		})
+	})
+
+	return node
+}
+
+type differ struct {
+	lines   []string
+	changed bool
+}
+
+func (differ *differ) line(mark byte, label string, depth int) {
+	if mark != ' ' {
+		differ.changed = true
+	}
+
+	indent := strings.Repeat(" ", depth*BranchIndent)
+
+	for _, line := range strings.Split(label, "\n") {
+		differ.lines = append(differ.lines, string(mark)+" "+indent+line)
+	}
+}
+
+func (differ *differ) write(mark byte, node diffNode, depth int) {
+	differ.line(mark, node.label, depth)
+
+	for _, child := range node.children {
+		differ.write(mark, child, depth+1)
+	}
+}
+
+func (differ *differ) diff(a, b diffNode, depth int) {
+	if a.label == b.label {
+		differ.line(' ', a.label, depth)
+	} else {
+		differ.line('-', a.label, depth)
+		differ.line('+', b.label, depth)
+	}
+
+	differ.diffChildren(a.children, b.children, depth+1)
+}
+
+// diffChildren matches children with the same labels using longest common
+// subsequence, matched children are compared recursively.
+func (differ *differ) diffChildren(a, b []diffNode, depth int) {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i].label == b[j].label:
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i].label == b[j].label:
+			differ.diff(a[i], b[j], depth)
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			differ.write('-', a[i], depth)
+			i++
+		default:
+			differ.write('+', b[j], depth)
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		differ.write('-', a[i], depth)
+	}
+
+	for ; j < len(b); j++ {
+		differ.write('+', b[j], depth)
+	}
+}
@@ -0,0 +1,79 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff_ShowsAddedRemovedAndChangedNodes(t *testing.T) {
+	test := assert.New(t)
+
+	a := Describe("host", "example.com").Format(
+		[]Reason{errors.New("timeout"), errors.New("refused")},
+		"unable to connect",
+	)
+
+	b := Describe("host", "example.org").Format(
+		[]Reason{errors.New("timeout"), errors.New("reset")},
+		"unable to connect",
+	)
+
+	test.Equal(
+		output(
+			"  unable to connect",
+			"     timeout",
+			"-    refused",
+			"-    host: example.com",
+			"+    reset",
+			"+    host: example.org",
+		),
+		Diff(a, b),
+	)
+}
+
+func TestDiff_ComparesNestedLevels(t *testing.T) {
+	test := assert.New(t)
+
+	a := Format(Format(errors.New("timeout"), "unable to dial"), "top")
+	b := Format(Format(errors.New("timeout"), "unable to resolve"), "top")
+
+	test.Equal(
+		output(
+			"  top",
+			"-    unable to dial",
+			"-       timeout",
+			"+    unable to resolve",
+			"+       timeout",
+		),
+		Diff(a, b),
+	)
+
+	test.Equal(
+		output(
+			"- old",
+			"+ new",
+			"     timeout",
+		),
+		Diff(
+			Format(errors.New("timeout"), "old"),
+			Format(errors.New("timeout"), "new"),
+		),
+	)
+}
+
+func TestDiff_ReturnsEmptyStringForEqualErrors(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal("", Diff(nil, nil))
+	test.Equal(
+		"",
+		Diff(
+			Describe("a", 1).Format(errors.New("b"), "c"),
+			Describe("a", 1).Format(errors.New("b"), "c"),
+		),
+	)
+	test.Equal("+ new", Diff(nil, errors.New("new")))
+	test.Equal("- old", Diff(errors.New("old"), nil))
+}
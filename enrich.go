@@ -0,0 +1,60 @@
+package karma
+
+import (
+	"fmt"
+)
+
+// MergeContextUp controls Enrich() behaviour: if set to true, contexts of
+// nested messages will be promoted to the enriched message level.
+var MergeContextUp = false
+
+// Enrich adds message to the given error without creating new nesting level
+// if error is already hierarchical: specified message is merged with the
+// existing top-level message. Otherwise Enrich behaves like Format().
+func Enrich(err error, message string, args ...interface{}) Karma {
+	karma, ok := getKarma(err)
+	if !ok {
+		return Format(err, message, args...)
+	}
+
+	result := *karma
+
+	message = fmt.Sprintf(message, args...)
+	if result.Message != "" {
+		result.Message = message + ": " + result.Message
+	} else {
+		result.Message = message
+	}
+
+	if MergeContextUp {
+		result = mergeContextUp(result)
+	}
+
+	return result
+}
+
+func mergeContextUp(karma Karma) Karma {
+	reasons := karma.GetReasons()
+	if len(reasons) == 0 {
+		return karma
+	}
+
+	merged := make([]Reason, len(reasons))
+	for index, reason := range reasons {
+		if nested, ok := reason.(Karma); ok {
+			karma.Context = appendContext(karma.Context, nested.Context)
+			nested.Context = nil
+			reason = nested
+		}
+
+		merged[index] = reason
+	}
+
+	if len(merged) == 1 {
+		karma.Reason = merged[0]
+	} else {
+		karma.Reason = merged
+	}
+
+	return karma
+}
@@ -0,0 +1,68 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrich_MergesMessageOfHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		errors.New("connection refused"),
+		"unable to dial",
+	)
+
+	test.EqualError(
+		Enrich(err, "unable to connect to %s", "database"),
+		output(
+			"unable to connect to database: unable to dial",
+			"├─ connection refused",
+			"└─ host: example.com",
+		),
+	)
+}
+
+func TestEnrich_FormatsNonHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		Enrich(errors.New("connection refused"), "unable to connect"),
+		output(
+			"unable to connect",
+			"└─ connection refused",
+		),
+	)
+}
+
+func TestEnrich_CanMergeContextUp(t *testing.T) {
+	test := assert.New(t)
+
+	defer func() {
+		MergeContextUp = false
+	}()
+
+	MergeContextUp = true
+
+	err := Describe("host", "example.com").Format(
+		Describe("port", 5432).Format(
+			errors.New("connection refused"),
+			"unable to dial",
+		),
+		"unable to connect",
+	)
+
+	test.EqualError(
+		Enrich(err, "unable to start"),
+		output(
+			"unable to start: unable to connect",
+			"├─ unable to dial",
+			"│  └─ connection refused",
+			"│",
+			"├─ host: example.com",
+			"└─ port: 5432",
+		),
+	)
+}
@@ -0,0 +1,24 @@
+package karma
+
+import (
+	"os"
+)
+
+// EnvKeyPrefix is a prefix of context keys, which are used to store values of
+// environment variables.
+const EnvKeyPrefix = "_env_"
+
+// WithEnv returns copy of given message with values of specified environment
+// variables added to its context. Variables which are not set are omitted.
+func WithEnv(err Karma, vars ...string) Karma {
+	for _, name := range vars {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		err = err.Annotate(EnvKeyPrefix+name, value)
+	}
+
+	return err
+}
@@ -0,0 +1,37 @@
+package karma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithEnv_AddsSetVariables(t *testing.T) {
+	test := assert.New(t)
+
+	t.Setenv("KARMA_TEST_REGION", "eu-west-1")
+	t.Setenv("KARMA_TEST_EMPTY", "")
+
+	test.EqualError(
+		WithEnv(
+			Format(nil, "unable to deploy"),
+			"KARMA_TEST_REGION",
+			"KARMA_TEST_UNSET",
+			"KARMA_TEST_EMPTY",
+		),
+		output(
+			"unable to deploy",
+			"├─ _env_KARMA_TEST_REGION: eu-west-1",
+			"└─ _env_KARMA_TEST_EMPTY: <empty>",
+		),
+	)
+}
+
+func TestWithEnv_OmitsUnsetVariables(t *testing.T) {
+	test := assert.New(t)
+
+	err := WithEnv(Format(nil, "unable to deploy"), "KARMA_TEST_UNSET")
+
+	test.Nil(err.GetContext())
+	test.EqualError(err, "unable to deploy")
+}
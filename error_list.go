@@ -0,0 +1,97 @@
+package karma
+
+import (
+	"sort"
+	"strings"
+)
+
+// ErrorList represents list of errors, which can be used as error itself.
+type ErrorList []error
+
+// assert that ErrorList can be sorted by error messages
+var _ sort.Interface = ErrorList(nil)
+
+// Error implements error interface, returns messages of all non-nil errors
+// separated by newlines.
+func (list ErrorList) Error() string {
+	messages := []string{}
+	for _, err := range list {
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Add returns new list with specified error appended. Nil error is ignored.
+func (list ErrorList) Add(err error) ErrorList {
+	if err == nil {
+		return list
+	}
+
+	return append(list[:len(list):len(list)], err)
+}
+
+// Filter returns new list with errors, for which given callback returns true.
+func (list ErrorList) Filter(fn func(error) bool) ErrorList {
+	var result ErrorList
+	for _, err := range list {
+		if fn(err) {
+			result = append(result, err)
+		}
+	}
+
+	return result
+}
+
+// First returns first error of the list or nil if list is empty.
+func (list ErrorList) First() error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return list[0]
+}
+
+// Last returns last error of the list or nil if list is empty.
+func (list ErrorList) Last() error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return list[len(list)-1]
+}
+
+// ToKarma creates hierarchical message with all non-nil errors of the list
+// as reasons.
+func (list ErrorList) ToKarma(message string) Karma {
+	reasons := make([]Reason, len(list))
+	for index, err := range list {
+		reasons[index] = err
+	}
+
+	return Push(Karma{Message: message}, reasons...)
+}
+
+// Unwrap returns errors of the list, so errors.Is() and errors.As() can
+// be used with the list.
+func (list ErrorList) Unwrap() []error {
+	return list
+}
+
+// Len is the number of errors in the list.
+func (list ErrorList) Len() int {
+	return len(list)
+}
+
+// Less reports whether error with index i should be sorted before error with
+// index j, errors are sorted by messages.
+func (list ErrorList) Less(i, j int) bool {
+	return stringReason(list[i]) < stringReason(list[j])
+}
+
+// Swap swaps errors with indexes i and j.
+func (list ErrorList) Swap(i, j int) {
+	list[i], list[j] = list[j], list[i]
+}
@@ -0,0 +1,80 @@
+package karma
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorList_AddsNonNilErrors(t *testing.T) {
+	test := assert.New(t)
+
+	var list ErrorList
+
+	list = list.Add(io.EOF).Add(nil).Add(os.ErrNotExist)
+
+	test.Len(list, 2)
+	test.Equal(io.EOF, list.First())
+	test.Equal(os.ErrNotExist, list.Last())
+	test.EqualError(list, output("EOF", "file does not exist"))
+}
+
+func TestErrorList_ReturnsNilForEmptyList(t *testing.T) {
+	test := assert.New(t)
+
+	var list ErrorList
+
+	test.Nil(list.First())
+	test.Nil(list.Last())
+}
+
+func TestErrorList_CanBeFiltered(t *testing.T) {
+	test := assert.New(t)
+
+	list := ErrorList{io.EOF, os.ErrNotExist, io.ErrUnexpectedEOF}
+
+	test.Equal(
+		ErrorList{io.EOF, io.ErrUnexpectedEOF},
+		list.Filter(func(err error) bool {
+			return err != os.ErrNotExist
+		}),
+	)
+}
+
+func TestErrorList_CanBeSorted(t *testing.T) {
+	test := assert.New(t)
+
+	list := ErrorList{os.ErrNotExist, io.ErrUnexpectedEOF, io.EOF}
+
+	sort.Sort(list)
+
+	test.Equal(ErrorList{io.EOF, os.ErrNotExist, io.ErrUnexpectedEOF}, list)
+}
+
+func TestErrorList_CanBeConvertedToKarma(t *testing.T) {
+	test := assert.New(t)
+
+	list := ErrorList{io.EOF, nil, os.ErrNotExist}
+
+	test.EqualError(
+		list.ToKarma("unable to read"),
+		output(
+			"unable to read",
+			"├─ EOF",
+			"└─ file does not exist",
+		),
+	)
+}
+
+func TestErrorList_CanBeUnwrapped(t *testing.T) {
+	test := assert.New(t)
+
+	list := ErrorList{io.EOF, os.ErrNotExist}
+
+	test.True(errors.Is(list, os.ErrNotExist))
+	test.False(errors.Is(list, os.ErrInvalid))
+}
@@ -0,0 +1,68 @@
+package karma
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+var errorTypeExtractors = struct {
+	sync.RWMutex
+	byType map[reflect.Type]func(error) *Context
+
+	// count is a number of registered extractors, it allows Format() to skip
+	// locking and reflection when no extractors are registered
+	count atomic.Int32
+}{
+	byType: map[reflect.Type]func(error) *Context{},
+}
+
+// RegisterErrorType registers extractor for error type T. When reason passed
+// to Format() has exactly type T, extractor is called and returned context is
+// added to the context of new message.
+func RegisterErrorType[T error](extractor func(T) *Context) {
+	errorTypeExtractors.Lock()
+	defer errorTypeExtractors.Unlock()
+
+	errorTypeExtractors.byType[errorType[T]()] = func(err error) *Context {
+		return extractor(err.(T))
+	}
+
+	errorTypeExtractors.count.Store(int32(len(errorTypeExtractors.byType)))
+}
+
+// UnregisterErrorType removes extractor previously registered for error type
+// T by RegisterErrorType.
+func UnregisterErrorType[T error]() {
+	errorTypeExtractors.Lock()
+	defer errorTypeExtractors.Unlock()
+
+	delete(errorTypeExtractors.byType, errorType[T]())
+
+	errorTypeExtractors.count.Store(int32(len(errorTypeExtractors.byType)))
+}
+
+func errorType[T error]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func extractErrorTypeContext(reason Reason) *Context {
+	if errorTypeExtractors.count.Load() == 0 {
+		return nil
+	}
+
+	err, ok := reason.(error)
+	if !ok {
+		return nil
+	}
+
+	errorTypeExtractors.RLock()
+	extractor, ok := errorTypeExtractors.byType[reflect.TypeOf(err)]
+	errorTypeExtractors.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	return extractor(err)
+}
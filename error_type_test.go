@@ -0,0 +1,89 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type notFoundError struct {
+	resource string
+}
+
+func (err notFoundError) Error() string {
+	return "not found"
+}
+
+func TestRegisterErrorType_ExtractsContextFromReason(t *testing.T) {
+	test := assert.New(t)
+
+	RegisterErrorType(func(err notFoundError) *Context {
+		return Describe("resource", err.resource)
+	})
+	defer UnregisterErrorType[notFoundError]()
+
+	test.EqualError(
+		Describe("id", 42).Format(
+			notFoundError{resource: "user"},
+			"unable to get user",
+		),
+		output(
+			"unable to get user",
+			"├─ not found",
+			"├─ id: 42",
+			"└─ resource: user",
+		),
+	)
+
+	test.EqualError(
+		Format(errors.New("not found"), "unable to get user"),
+		output(
+			"unable to get user",
+			"└─ not found",
+		),
+	)
+}
+
+func TestUnregisterErrorType_RemovesExtractor(t *testing.T) {
+	test := assert.New(t)
+
+	RegisterErrorType(func(err notFoundError) *Context {
+		return Describe("resource", err.resource)
+	})
+	UnregisterErrorType[notFoundError]()
+
+	test.EqualError(
+		Format(notFoundError{resource: "user"}, "unable to get user"),
+		output(
+			"unable to get user",
+			"└─ not found",
+		),
+	)
+}
+
+func TestRegisterErrorType_DoesNotDuplicateContextOnLift(t *testing.T) {
+	test := assert.New(t)
+
+	RegisterErrorType(func(err notFoundError) *Context {
+		return Describe("resource", err.resource)
+	})
+	defer UnregisterErrorType[notFoundError]()
+
+	err := Format(
+		Format(
+			Format(notFoundError{resource: "user"}, "unable to get user"),
+			"",
+		),
+		"",
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to get user",
+			"├─ not found",
+			"└─ resource: user",
+		),
+	)
+}
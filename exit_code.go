@@ -0,0 +1,40 @@
+package karma
+
+// ExitCodeKey is a context key which is used to store process exit code.
+const ExitCodeKey = "_exit_code"
+
+// WithExitCode returns copy of given message with process exit code added to
+// its context.
+func WithExitCode(err Karma, code int) Karma {
+	return err.Annotate(ExitCodeKey, code)
+}
+
+// GetExitCode returns process exit code previously associated with error
+// using WithExitCode() at any level of the hierarchy.
+func GetExitCode(err error) (int, bool) {
+	value, ok := findContextValue(err, ExitCodeKey)
+	if !ok {
+		return 0, false
+	}
+
+	code, ok := contextInt(value)
+
+	return int(code), ok
+}
+
+// ExitCodeOr returns process exit code associated with error or defaultCode
+// if there is no such code. Exit code for nil error is always 0:
+//
+//	os.Exit(karma.ExitCodeOr(err, 1))
+func ExitCodeOr(err error, defaultCode int) int {
+	if err == nil {
+		return 0
+	}
+
+	code, ok := GetExitCode(err)
+	if !ok {
+		return defaultCode
+	}
+
+	return code
+}
@@ -0,0 +1,36 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithExitCode_AddsCodeToContext(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		WithExitCode(Format(errors.New("no such file"), "unable to read"), 2),
+		"unable to start",
+	)
+
+	code, ok := GetExitCode(err)
+	test.True(ok)
+	test.Equal(2, code)
+
+	restored, unmarshalErr := NewFromJSONString(JSON(err))
+	test.NoError(unmarshalErr)
+
+	code, ok = GetExitCode(restored)
+	test.True(ok)
+	test.Equal(2, code)
+}
+
+func TestExitCodeOr_ReturnsDefaultCode(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(0, ExitCodeOr(nil, 1))
+	test.Equal(1, ExitCodeOr(errors.New("plain"), 1))
+	test.Equal(3, ExitCodeOr(WithExitCode(Format(nil, "usage"), 3), 1))
+}
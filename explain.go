@@ -0,0 +1,165 @@
+package karma
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// Explanation represents error hierarchy prepared for human-readable
+// explanation, it is passed to template by ExplainWithTemplate().
+type Explanation struct {
+	// Message is a message of the current level.
+	Message string
+
+	// Context is a context of the current level.
+	Context []KeyValue
+
+	// Causes are explanations of nested reasons.
+	Causes []Explanation
+
+	// Depth is a nesting level, top-level has depth 0.
+	Depth int
+}
+
+// Explain returns human-readable multi-paragraph explanation of given error,
+// like:
+//
+//	Operation failed: unable to connect. This was caused by: dial failed.
+//	The following context was available: host=example.com.
+//
+//	Going deeper, dial failed was caused by: connection refused.
+func Explain(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	paragraphs := []string{}
+
+	var walk func(Explanation)
+	walk = func(explanation Explanation) {
+		paragraph := explainParagraph(explanation)
+		if paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+
+		for _, cause := range explanation.Causes {
+			walk(cause)
+		}
+	}
+
+	walk(explain(err, 0))
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// ExplainWithTemplate executes given template with Explanation of error.
+func ExplainWithTemplate(err error, tmpl *template.Template) (string, error) {
+	var buffer bytes.Buffer
+
+	execErr := tmpl.Execute(&buffer, explain(err, 0))
+	if execErr != nil {
+		return "", Format(execErr, "unable to execute explanation template")
+	}
+
+	return buffer.String(), nil
+}
+
+func explain(reason Reason, depth int) Explanation {
+	karma, ok := getKarma(reason)
+	if !ok {
+		return Explanation{
+			Message: stringReason(reason),
+			Depth:   depth,
+		}
+	}
+
+	var explanation Explanation
+
+	reasons := karma.GetReasons()
+	if karma.Message == "" && len(reasons) == 1 {
+		explanation = explain(reasons[0], depth)
+	} else {
+		explanation = Explanation{
+			Message: karma.Message,
+			Depth:   depth,
+		}
+
+		for _, reason := range reasons {
+			explanation.Causes = append(
+				explanation.Causes,
+				explain(reason, depth+1),
+			)
+		}
+	}
+
+	explanation.Context = append(
+		explanation.Context,
+		karma.Context.GetKeyValues()...,
+	)
+
+	return explanation
+}
+
+func explainParagraph(explanation Explanation) string {
+	sentences := []string{}
+
+	causes := make([]string, len(explanation.Causes))
+	for index, cause := range explanation.Causes {
+		causes[index] = trimSentence(cause.Message)
+	}
+
+	message := trimSentence(explanation.Message)
+
+	switch {
+	case explanation.Depth == 0:
+		sentences = append(sentences, "Operation failed: "+message+".")
+		if len(causes) > 0 {
+			sentences = append(
+				sentences,
+				"This was caused by: "+strings.Join(causes, ", ")+".",
+			)
+		}
+	case len(causes) > 0:
+		sentences = append(
+			sentences,
+			explainDepth(explanation.Depth)+message+
+				" was caused by: "+strings.Join(causes, ", ")+".",
+		)
+	case len(explanation.Context) > 0:
+		sentences = append(
+			sentences,
+			explainDepth(explanation.Depth)+message+" happened.",
+		)
+	default:
+		return ""
+	}
+
+	if len(explanation.Context) > 0 {
+		pairs := make([]string, len(explanation.Context))
+		for index, pair := range explanation.Context {
+			pairs[index] = pair.Key + "=" +
+				formatContextValue(pair.Key, pair.Value)
+		}
+
+		sentences = append(
+			sentences,
+			"The following context was available: "+
+				strings.Join(pairs, ", ")+".",
+		)
+	}
+
+	return strings.Join(sentences, " ")
+}
+
+func explainDepth(depth int) string {
+	if depth == 1 {
+		return "Going deeper, "
+	}
+
+	return "Going even deeper, "
+}
+
+func trimSentence(message string) string {
+	return strings.TrimRight(strings.TrimSpace(message), ".")
+}
@@ -0,0 +1,81 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain_ExplainsSimpleError(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(
+		"Operation failed: connection refused.",
+		Explain(errors.New("connection refused")),
+	)
+
+	test.Equal("", Explain(nil))
+}
+
+func TestExplain_ExplainsHierarchy(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		[]Reason{
+			Describe("port", 5432).Format(
+				Describe("attempt", 3).Reason(errors.New("connection refused")),
+				"dial failed.",
+			),
+			errors.New("timeout"),
+		},
+		"unable to connect",
+	)
+
+	test.Equal(
+		output(
+			"Operation failed: unable to connect. "+
+				"This was caused by: dial failed, timeout. "+
+				"The following context was available: host=example.com.",
+			"",
+			"Going deeper, dial failed was caused by: connection refused. "+
+				"The following context was available: port=5432.",
+			"",
+			"Going even deeper, connection refused happened. "+
+				"The following context was available: attempt=3.",
+		),
+		Explain(err),
+	)
+}
+
+func TestExplainWithTemplate_ExecutesTemplate(t *testing.T) {
+	test := assert.New(t)
+
+	tmpl := template.Must(template.New("explain").Parse(
+		`{{.Message}}:{{range .Causes}} {{.Message}}{{end}}` +
+			`{{range .Context}} ({{.Key}}={{.Value}}){{end}}`,
+	))
+
+	result, err := ExplainWithTemplate(
+		Describe("host", "example.com").Format(
+			errors.New("connection refused"),
+			"unable to connect",
+		),
+		tmpl,
+	)
+	test.NoError(err)
+	test.Equal(
+		"unable to connect: connection refused (host=example.com)",
+		result,
+	)
+}
+
+func TestExplainWithTemplate_ReturnsTemplateError(t *testing.T) {
+	test := assert.New(t)
+
+	tmpl := template.Must(template.New("explain").Parse(`{{.Unknown}}`))
+
+	_, err := ExplainWithTemplate(errors.New("failure"), tmpl)
+	test.Error(err)
+}
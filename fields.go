@@ -0,0 +1,34 @@
+package karma
+
+// ContextFromSortedMap creates context list from given map. Pairs are sorted
+// by key, so result does not depend on map iteration order.
+func ContextFromSortedMap(fields map[string]interface{}) *Context {
+	var context *Context
+
+	for _, key := range sortedKeys(fields) {
+		context = context.Describe(key, fields[key])
+	}
+
+	return context
+}
+
+// NewFromFields creates new hierarchical message with given reason, message
+// and context created from given fields, see ContextFromSortedMap(). Unlike
+// Format(), message is used as is and is not treated as format string.
+func NewFromFields(
+	err error,
+	message string,
+	fields map[string]interface{},
+) Karma {
+	var reason Reason
+	if err != nil {
+		reason = err
+	}
+
+	return newKarma(
+		ContextFromSortedMap(fields),
+		reason,
+		message,
+		GetDefaultContext(nil),
+	)
+}
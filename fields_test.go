@@ -0,0 +1,60 @@
+package karma
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextFromSortedMap_SortsPairsByKey(t *testing.T) {
+	test := assert.New(t)
+
+	context := ContextFromSortedMap(map[string]interface{}{
+		"port": 80,
+		"host": "example.com",
+		"user": "root",
+	})
+
+	test.Equal(
+		[]KeyValue{{"host", "example.com"}, {"port", 80}, {"user", "root"}},
+		context.GetKeyValues(),
+	)
+
+	test.Nil(ContextFromSortedMap(nil))
+}
+
+func TestNewFromFields_CreatesMessageWithContext(t *testing.T) {
+	test := assert.New(t)
+
+	err := NewFromFields(
+		errors.New("timeout"),
+		"unable to connect to 100% of hosts",
+		map[string]interface{}{
+			"port": 80,
+			"host": "example.com",
+		},
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect to 100% of hosts",
+			"├─ timeout",
+			"├─ host: example.com",
+			"└─ port: 80",
+		),
+	)
+
+	test.EqualError(
+		NewFromFields(nil, "unable to connect", nil),
+		"unable to connect",
+	)
+	test.Nil(NewFromFields(nil, "unable to connect", nil).Reason)
+
+	reason := fmt.Errorf("a: %w", io.EOF)
+
+	test.Equal(reason, NewFromFields(reason, "b", nil).Reason)
+}
@@ -1,12 +1,52 @@
 package karma
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 )
 
+type flattened struct {
+	message string
+	context *Context
+	reasons []error
+}
+
+func (flat *flattened) Error() string {
+	return flat.message
+}
+
+func (flat *flattened) Unwrap() []error {
+	return flat.reasons
+}
+
+// FlattenUnwrap returns the same error as Flatten(), but original error and
+// all its reasons can be accessed by errors.Is() and errors.As().
+func FlattenUnwrap(err error) error {
+	if IsEmpty(err) {
+		return nil
+	}
+
+	flat, ok := Flatten(err).(*flattened)
+	if !ok {
+		return err
+	}
+
+	flat.reasons = []error{err}
+
+	err.(Karma).Descend(func(reason Reason) {
+		if reason, ok := reason.(error); ok {
+			flat.reasons = append(flat.reasons, reason)
+		}
+	})
+
+	return flat
+}
+
 func Flatten(err error) error {
+	if IsEmpty(err) {
+		return nil
+	}
+
 	if err, ok := err.(Karma); ok {
 		messages := []string{err.GetMessage()}
 		keyvalues := err.GetContext().GetKeyValuePairs()
@@ -30,16 +70,77 @@ func Flatten(err error) error {
 		)
 
 		if len(keyvalues) > 0 {
+			var context *Context
+
 			pairs := make([]string, len(keyvalues)/2)
 			for i := 0; i < len(keyvalues); i += 2 {
 				pairs[i/2] = fmt.Sprintf("%s=%v", keyvalues[i], keyvalues[i+1])
+
+				context = context.Describe(keyvalues[i].(string), keyvalues[i+1])
 			}
 
-			return errors.New(strings.Join(messages, ": ") + " | " + strings.Join(pairs, " "))
+			return &flattened{
+				message: strings.Join(messages, ": ") + " | " + strings.Join(pairs, " "),
+				context: context,
+			}
 		} else {
-			return errors.New(strings.Join(messages, ": "))
+			return &flattened{
+				message: strings.Join(messages, ": "),
+			}
 		}
 	}
 
 	return err
 }
+
+// Chain creates strict chain of hierarchical messages, where first error is
+// caused by second, second is caused by third and so on: every error except
+// the last one becomes level with its message and context and the last error
+// is kept as is. It's the inverse of Flatten(). Nil errors are skipped.
+func Chain(message string, errs ...error) Karma {
+	var reason Reason
+
+	for i := len(errs) - 1; i >= 0; i-- {
+		err := errs[i]
+		if err == nil {
+			continue
+		}
+
+		if reason == nil {
+			reason = err
+			continue
+		}
+
+		level := Karma{
+			Message: err.Error(),
+			Reason:  reason,
+		}
+
+		if karma, ok := getKarma(err); ok {
+			level.Message = karma.GetMessage()
+			level.Context = karma.Context
+		}
+
+		reason = level
+	}
+
+	return newKarma(nil, reason, message, GetDefaultContext(nil))
+}
+
+// Flatten1 is the same as Format(), but if reason is hierarchical message,
+// its reasons become reasons of new message and its context is merged into
+// context of new message, so no new nesting level is created. Message of
+// reason is dropped.
+func Flatten1(reason Reason, message string, args ...interface{}) Karma {
+	karma, ok := getKarma(reason)
+	if !ok {
+		return Format(reason, message, args...)
+	}
+
+	return format(
+		karma.GetContext(),
+		joinReasons(karma.GetReasons()),
+		message,
+		args,
+	)
+}
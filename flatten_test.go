@@ -0,0 +1,146 @@
+package karma
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenUnwrap_KeepsReasonsAccessible(t *testing.T) {
+	test := assert.New(t)
+
+	original := Describe("path", "/tmp").Format(
+		Format(customSimpleError{"custom"}, "unable to stat"),
+		"unable to open",
+	)
+
+	err := FlattenUnwrap(original)
+
+	test.EqualError(err, "unable to open: unable to stat: custom | path=/tmp")
+	test.True(errors.Is(err, original))
+
+	var custom customSimpleError
+	test.True(errors.As(err, &custom))
+	test.Equal("custom", custom.text)
+
+	test.False(errors.Is(err, os.ErrNotExist))
+}
+
+func TestFlattenUnwrap_ReturnsNonHierarchicalErrorAsIs(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(io.EOF, FlattenUnwrap(io.EOF))
+}
+
+func TestFlatten_CanBeUsedAsReason(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		Format(Flatten(Format(io.EOF, "unable to read")), "unable to load"),
+		output(
+			"unable to load",
+			"└─ unable to read: EOF",
+		),
+	)
+
+	test.EqualError(
+		Format(FlattenUnwrap(Format(io.EOF, "unable to read")), "unable to load"),
+		output(
+			"unable to load",
+			"└─ unable to read: EOF",
+		),
+	)
+}
+
+func TestChain_CreatesNestedChain(t *testing.T) {
+	test := assert.New(t)
+
+	err := Chain(
+		"unable to start",
+		errors.New("unable to load config"),
+		Describe("path", "/etc/app.conf").Reason("unable to open file"),
+		errors.New("permission denied"),
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to start",
+			"└─ unable to load config",
+			"   └─ unable to open file",
+			"      ├─ permission denied",
+			"      └─ path: /etc/app.conf",
+		),
+	)
+
+	test.EqualError(
+		Flatten(err),
+		"unable to start: unable to load config: unable to open file: "+
+			"permission denied | path=/etc/app.conf",
+	)
+}
+
+func TestChain_SkipsNilErrors(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		Chain("top", nil, errors.New("leaf"), nil),
+		output(
+			"top",
+			"└─ leaf",
+		),
+	)
+
+	test.EqualError(Chain("alone"), "alone")
+}
+
+func TestFlatten1_ReplacesMessageOfHierarchicalReason(t *testing.T) {
+	test := assert.New(t)
+
+	err := Flatten1(
+		Describe("host", "example.com").Format(
+			Push("unable to dial", errors.New("timeout"), errors.New("refused")),
+			"unable to connect",
+		),
+		"unable to fetch %s",
+		"/index.html",
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to fetch /index.html",
+			"├─ unable to dial",
+			"│  ├─ timeout",
+			"│  └─ refused",
+			"│",
+			"└─ host: example.com",
+		),
+	)
+
+	err = Flatten1(
+		Push("unable to connect", errors.New("timeout"), errors.New("refused")),
+		"unable to fetch",
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to fetch",
+			"├─ timeout",
+			"└─ refused",
+		),
+	)
+}
+
+func TestFlatten1_FormatsNonHierarchicalReason(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(
+		Format(errors.New("timeout"), "unable to connect"),
+		Flatten1(errors.New("timeout"), "unable to connect"),
+	)
+}
@@ -0,0 +1,190 @@
+package karma
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// HTTPStatusKey is a context key which is used to store HTTP status code.
+const HTTPStatusKey = "_http_status"
+
+// HTTPResponseStatusKey is a context key which is used by WrapHTTPResponse()
+// to store status code of response.
+const HTTPResponseStatusKey = "http_status"
+
+// httpResponseBodyLimit is a maximum number of bytes of response body, which
+// will be added to the context by WrapHTTPResponse().
+const httpResponseBodyLimit = 4096
+
+// WithHTTPStatus returns copy of given message with HTTP status code added
+// to its context.
+func WithHTTPStatus(err Karma, code int) Karma {
+	return err.Annotate(HTTPStatusKey, code)
+}
+
+// GetHTTPStatus returns HTTP status code previously associated with error
+// using WithHTTPStatus() or WrapHTTPResponse() at any level of the hierarchy.
+// Status code is preserved by Flatten().
+func GetHTTPStatus(err error) (int, bool) {
+	value, ok := findContextValue(err, HTTPStatusKey)
+	if !ok {
+		value, ok = findContextValue(err, HTTPResponseStatusKey)
+		if !ok {
+			return 0, false
+		}
+	}
+
+	code, ok := contextInt(value)
+
+	return int(code), ok
+}
+
+// HTTPError creates new hierarchical message with associated HTTP status code.
+func HTTPError(
+	code int,
+	reason Reason,
+	message string,
+	args ...interface{},
+) Karma {
+	return WithHTTPStatus(Format(reason, message, args...), code)
+}
+
+// ToHTTPHeaders converts context into HTTP headers, so it can be propagated to
+// downstream services. Every key becomes header named prefix-key, e.g. key
+// "host" with prefix "X-Karma" becomes "X-Karma-Host" header. Characters of
+// key, which are not allowed in header names, are replaced with "_".
+//
+// Values are formatted same way as in String() if result is a single line,
+// otherwise they are encoded as compact JSON.
+func (context *Context) ToHTTPHeaders(prefix string) http.Header {
+	headers := http.Header{}
+
+	context.Walk(func(key string, value interface{}) {
+		headers.Add(
+			prefix+"-"+httpHeaderKey(key),
+			httpHeaderValue(key, value),
+		)
+	})
+
+	return headers
+}
+
+// httpHeaderKey replaces all characters of key, which are not HTTP token
+// characters, with "_".
+func httpHeaderKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+// httpHeaderValue formats context value as single line header value.
+func httpHeaderValue(key string, value interface{}) string {
+	result := formatContextValue(key, value)
+	if isHTTPHeaderValue(result) {
+		return result
+	}
+
+	if _, ok := value.(string); !ok {
+		data, err := json.Marshal(value)
+		if err == nil && isHTTPHeaderValue(string(data)) {
+			return string(data)
+		}
+	}
+
+	data, _ := json.Marshal(result)
+
+	return string(data)
+}
+
+// isHTTPHeaderValue reports whether value contains only characters allowed
+// in HTTP header values: no control characters except horizontal tab.
+func isHTTPHeaderValue(value string) bool {
+	for _, r := range value {
+		if (r < ' ' && r != '\t') || r == 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ContextFromHTTPHeaders creates context from HTTP headers produced by
+// ToHTTPHeaders(). Since header names are case-insensitive, keys are
+// lowercased and pairs are sorted by key.
+func ContextFromHTTPHeaders(headers http.Header, prefix string) *Context {
+	prefix = http.CanonicalHeaderKey(prefix + "-")
+
+	names := []string{}
+	for name := range headers {
+		canonical := http.CanonicalHeaderKey(name)
+		if len(canonical) > len(prefix) &&
+			strings.HasPrefix(canonical, prefix) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	var context *Context
+
+	for _, name := range names {
+		key := strings.ToLower(http.CanonicalHeaderKey(name)[len(prefix):])
+
+		for _, value := range headers[name] {
+			context = context.Describe(key, value)
+		}
+	}
+
+	return context
+}
+
+// WrapHTTPResponse creates new hierarchical message from failed HTTP response
+// with response status as reason. Status code, URL and method of request, and
+// up to 4KB of response body are added to the context. Response body is
+// restored, so it still can be read completely by caller.
+func WrapHTTPResponse(resp *http.Response, message string) Karma {
+	if resp == nil {
+		return Format(nil, "%s", message)
+	}
+
+	context := Describe(HTTPResponseStatusKey, resp.StatusCode)
+
+	if resp.Request != nil {
+		if resp.Request.URL != nil {
+			context = context.Describe("http_url", resp.Request.URL.String())
+		}
+
+		context = context.Describe("http_method", resp.Request.Method)
+	}
+
+	if resp.Body != nil {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, httpResponseBodyLimit))
+
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{
+			io.MultiReader(bytes.NewReader(body), resp.Body),
+			resp.Body,
+		}
+
+		context = context.Describe("http_body", string(body))
+	}
+
+	return context.Format(resp.Status, "%s", message)
+}
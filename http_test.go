@@ -0,0 +1,232 @@
+package karma
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPError_AddsStatusToContext(t *testing.T) {
+	test := assert.New(t)
+
+	err := HTTPError(
+		http.StatusNotFound,
+		errors.New("no such user"),
+		"unable to find user %q",
+		"root",
+	)
+
+	test.EqualError(
+		err,
+		output(
+			`unable to find user "root"`,
+			"├─ no such user",
+			"└─ _http_status: 404",
+		),
+	)
+
+	code, ok := GetHTTPStatus(err)
+	test.True(ok)
+	test.Equal(http.StatusNotFound, code)
+}
+
+func TestGetHTTPStatus_SearchesNestedMessages(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		WithHTTPStatus(Format(nil, "forbidden"), http.StatusForbidden),
+		"unable to handle request",
+	)
+
+	code, ok := GetHTTPStatus(err)
+	test.True(ok)
+	test.Equal(http.StatusForbidden, code)
+
+	code, ok = GetHTTPStatus(WithHTTPStatus(err, http.StatusBadGateway))
+	test.True(ok)
+	test.Equal(http.StatusBadGateway, code)
+}
+
+func TestGetHTTPStatus_ReturnsFalseWithoutStatus(t *testing.T) {
+	test := assert.New(t)
+
+	_, ok := GetHTTPStatus(Format(nil, "no status"))
+	test.False(ok)
+
+	_, ok = GetHTTPStatus(errors.New("no status"))
+	test.False(ok)
+
+	_, ok = GetHTTPStatus(nil)
+	test.False(ok)
+}
+
+func TestGetHTTPStatus_PreservedThroughFlatten(t *testing.T) {
+	test := assert.New(t)
+
+	err := Flatten(
+		Format(HTTPError(http.StatusConflict, nil, "conflict"), "unable to save"),
+	)
+
+	test.EqualError(err, "unable to save: conflict | _http_status=409")
+
+	code, ok := GetHTTPStatus(err)
+	test.True(ok)
+	test.Equal(http.StatusConflict, code)
+}
+
+func TestGetHTTPStatus_PreservedThroughJSON(t *testing.T) {
+	test := assert.New(t)
+
+	data, err := json.Marshal(HTTPError(http.StatusTeapot, nil, "teapot"))
+	test.NoError(err)
+
+	var restored Karma
+	test.NoError(json.Unmarshal(data, &restored))
+
+	code, ok := GetHTTPStatus(restored)
+	test.True(ok)
+	test.Equal(http.StatusTeapot, code)
+}
+
+func TestContext_ToHTTPHeaders_ConvertsPairsToHeaders(t *testing.T) {
+	test := assert.New(t)
+
+	headers := Describe("host", "example.com").
+		Describe("request_id", 42).
+		ToHTTPHeaders("X-Karma")
+
+	test.Equal(
+		http.Header{
+			"X-Karma-Host":       []string{"example.com"},
+			"X-Karma-Request_id": []string{"42"},
+		},
+		headers,
+	)
+}
+
+func TestContext_ToHTTPHeaders_ProducesValidHeaders(t *testing.T) {
+	test := assert.New(t)
+
+	headers := Describe("user", struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}{"root", 42}).
+		Describe("body", map[string]int{"a": 1}).
+		DescribeJSON("payload", []byte(`{"id": 1,`+"\n"+`"tags": ["a"]}`)).
+		Describe("text", "line one\nline two").
+		Describe("request id", 1).
+		Describe("path:a/b", "/").
+		ToHTTPHeaders("X-Karma")
+
+	test.Equal(
+		http.Header{
+			"X-Karma-User":       []string{`{"name":"root","age":42}`},
+			"X-Karma-Body":       []string{`{"a":1}`},
+			"X-Karma-Payload":    []string{`{"id":1,"tags":["a"]}`},
+			"X-Karma-Text":       []string{`"line one\nline two"`},
+			"X-Karma-Request_id": []string{"1"},
+			"X-Karma-Path_a_b":   []string{"/"},
+		},
+		headers,
+	)
+
+	for name, values := range headers {
+		request, err := http.NewRequest("GET", "http://example.com", nil)
+		test.NoError(err)
+
+		request.Header[name] = values
+
+		test.NoError(request.Write(io.Discard), name)
+	}
+}
+
+func TestContextFromHTTPHeaders_RestoresContext(t *testing.T) {
+	test := assert.New(t)
+
+	headers := Describe("port", 80).
+		Describe("host", "example.com").
+		ToHTTPHeaders("x-karma")
+	headers.Set("Content-Type", "text/plain")
+
+	test.Equal(
+		[]interface{}{"host", "example.com", "port", "80"},
+		ContextFromHTTPHeaders(headers, "X-Karma").GetKeyValuePairs(),
+	)
+
+	test.Nil(ContextFromHTTPHeaders(http.Header{}, "X-Karma"))
+}
+
+func TestWrapHTTPResponse_AddsResponseInfoToContext(t *testing.T) {
+	test := assert.New(t)
+
+	request, _ := http.NewRequest("POST", "http://example.com/users", nil)
+
+	resp := &http.Response{
+		Status:     "409 Conflict",
+		StatusCode: http.StatusConflict,
+		Request:    request,
+		Body:       io.NopCloser(strings.NewReader("user already exists")),
+	}
+
+	test.EqualError(
+		WrapHTTPResponse(resp, "unable to create user"),
+		output(
+			"unable to create user",
+			"├─ 409 Conflict",
+			"├─ http_status: 409",
+			"├─ http_url: http://example.com/users",
+			"├─ http_method: POST",
+			"└─ http_body: user already exists",
+		),
+	)
+
+	body, err := io.ReadAll(resp.Body)
+	test.NoError(err)
+	test.Equal("user already exists", string(body))
+}
+
+func TestWrapHTTPResponse_StatusCanBeReadByGetHTTPStatus(t *testing.T) {
+	test := assert.New(t)
+
+	resp := &http.Response{
+		Status:     "404 Not Found",
+		StatusCode: http.StatusNotFound,
+	}
+
+	err := Format(
+		WrapHTTPResponse(resp, "unable to get user"),
+		"unable to sync users",
+	)
+
+	code, ok := GetHTTPStatus(err)
+	test.True(ok)
+	test.Equal(http.StatusNotFound, code)
+}
+
+func TestWrapHTTPResponse_LimitsBody(t *testing.T) {
+	test := assert.New(t)
+
+	data := strings.Repeat("x", 5000)
+
+	resp := &http.Response{
+		Status:     "500 Internal Server Error",
+		StatusCode: http.StatusInternalServerError,
+		Body:       io.NopCloser(strings.NewReader(data)),
+	}
+
+	err := WrapHTTPResponse(resp, "request failed")
+
+	body, ok := err.GetContext().lookup("http_body")
+	test.True(ok)
+	test.Len(body, 4096)
+
+	restored, _ := io.ReadAll(resp.Body)
+	test.Equal(data, string(restored))
+
+	test.EqualError(WrapHTTPResponse(nil, "no response"), "no response")
+}
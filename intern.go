@@ -0,0 +1,43 @@
+package karma
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	keyInterning atomic.Bool
+	internedKeys sync.Map
+)
+
+// EnableKeyInterning enables interning of context keys: all context keys with
+// the same value will share the same memory. It is useful for services
+// producing lots of messages with the same context keys.
+func EnableKeyInterning() {
+	keyInterning.Store(true)
+}
+
+// DisableKeyInterning disables interning of context keys and releases all
+// previously interned keys.
+func DisableKeyInterning() {
+	keyInterning.Store(false)
+
+	internedKeys.Range(func(key, _ interface{}) bool {
+		internedKeys.Delete(key)
+		return true
+	})
+}
+
+func internKey(key string) string {
+	if !keyInterning.Load() {
+		return key
+	}
+
+	if interned, ok := internedKeys.Load(key); ok {
+		return interned.(string)
+	}
+
+	interned, _ := internedKeys.LoadOrStore(key, key)
+
+	return interned.(string)
+}
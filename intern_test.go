@@ -0,0 +1,51 @@
+package karma
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stringData(value string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&value)).Data
+}
+
+func TestKeyInterning_SharesKeys(t *testing.T) {
+	test := assert.New(t)
+
+	EnableKeyInterning()
+	defer DisableKeyInterning()
+
+	first := Describe(string([]byte("host")), "a")
+	second := Describe("port", 1).Describe(string([]byte("host")), "b")
+
+	test.Equal("host", first.Key)
+	test.Equal("host", second.Next.Key)
+	test.Equal(stringData(first.Key), stringData(second.Next.Key))
+}
+
+func TestKeyInterning_DisabledByDefault(t *testing.T) {
+	test := assert.New(t)
+
+	first := Describe(string([]byte("host")), "a")
+	second := Describe(string([]byte("host")), "b")
+
+	test.NotEqual(stringData(first.Key), stringData(second.Key))
+}
+
+func TestKeyInterning_DoesNotAllocateForKnownKeys(t *testing.T) {
+	test := assert.New(t)
+
+	EnableKeyInterning()
+	defer DisableKeyInterning()
+
+	key := string([]byte("host"))
+
+	internKey(key)
+
+	test.Zero(testing.AllocsPerRun(100, func() {
+		internKey(key)
+	}))
+}
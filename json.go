@@ -0,0 +1,60 @@
+package karma
+
+import (
+	"encoding/json"
+)
+
+// JSON returns compact JSON representation of given error. If error is not
+// hierarchical, it will be represented as a message. If marshaling fails,
+// `{}` will be returned.
+func JSON(err error) string {
+	data, marshalErr := json.Marshal(toKarma(err))
+	if marshalErr != nil {
+		return "{}"
+	}
+
+	return string(data)
+}
+
+// JSONP returns pretty-printed JSON representation of given error, indented
+// by two spaces. See JSON() for details.
+func JSONP(err error) string {
+	data, marshalErr := json.MarshalIndent(toKarma(err), "", "  ")
+	if marshalErr != nil {
+		return "{}"
+	}
+
+	return string(data)
+}
+
+func toKarma(err error) interface{} {
+	if err == nil {
+		return nil
+	}
+
+	if karma, ok := getKarma(err); ok {
+		return karma
+	}
+
+	return Karma{
+		Message: err.Error(),
+	}
+}
+
+// NewFromJSON creates hierarchical message from its JSON representation,
+// which can be obtained using JSON() or json.Marshal().
+func NewFromJSON(data []byte) (Karma, error) {
+	var karma Karma
+
+	err := json.Unmarshal(data, &karma)
+	if err != nil {
+		return Karma{}, err
+	}
+
+	return karma, nil
+}
+
+// NewFromJSONString is the same as NewFromJSON(), but accepts string.
+func NewFromJSONString(data string) (Karma, error) {
+	return NewFromJSON([]byte(data))
+}
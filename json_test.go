@@ -0,0 +1,88 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSON_ReturnsCompactJSON(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		errors.New("access denied"),
+		"unable to connect",
+	)
+
+	test.Equal(
+		`{"reason":"access denied","message":"unable to connect",`+
+			`"context":[{"key":"host","value":"example.com"}]}`,
+		JSON(err),
+	)
+}
+
+func TestJSON_CanMarshalNonHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	test.JSONEq(
+		`{"reason":null,"message":"access denied"}`,
+		JSON(errors.New("access denied")),
+	)
+}
+
+func TestJSON_ReturnsNullForNilError(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal("null", JSON(nil))
+}
+
+func TestJSON_ReturnsEmptyObjectOnMarshalError(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(
+		"{}",
+		JSON(Describe("channel", make(chan int)).Format(nil, "unmarshalable")),
+	)
+}
+
+func TestJSONP_ReturnsIndentedJSON(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(
+		output(
+			`{`,
+			`  "reason": "access denied",`,
+			`  "message": "unable to connect"`,
+			`}`,
+		),
+		JSONP(Format(errors.New("access denied"), "unable to connect")),
+	)
+}
+
+func TestNewFromJSON_RestoresMarshaledError(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		Describe("free", "512Kb").Format(
+			errors.New("tcp: out of memory"),
+			"unable to allocate",
+		),
+		"unable to connect",
+	)
+
+	restored, unmarshalErr := NewFromJSONString(JSON(err))
+	test.NoError(unmarshalErr)
+	test.Equal(err.Error(), restored.Error())
+
+	restored, unmarshalErr = NewFromJSON([]byte(JSONP(err)))
+	test.NoError(unmarshalErr)
+	test.Equal(err.Error(), restored.Error())
+}
+
+func TestNewFromJSON_ReturnsErrorOnInvalidJSON(t *testing.T) {
+	test := assert.New(t)
+
+	_, err := NewFromJSONString(`{"message":`)
+	test.Error(err)
+}
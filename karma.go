@@ -10,15 +10,30 @@
 //	can't pull remote 'origin'
 //	└─ can't run git fetch 'origin' 'refs/tokens/*:refs/tokens/*'
 //	   └─ exit status 128
+//
+// # Migrating from fmt.Errorf
+//
+// Errors created by fmt.Errorf("...: %w", err) can be replaced with
+// WrapCompat(err, "..."): errors.Unwrap() returns err as is, and errors.Is()
+// and errors.As() work the same way.
+//
+// Format(err, "...") can be used as well, errors.Is() and errors.As() will
+// still find err and any error nested in it. However, Format() treats message
+// as format string and splits errors wrapping multiple errors, like
+// errors.Join() result, into separate branches, so errors.Unwrap() returns
+// err only if it is single error.
 package karma // import "github.com/reconquest/karma-go"
 
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 )
 
@@ -87,6 +102,19 @@ type Karma struct {
 	// Context is a key-pair linked list, which represents runtime context
 	// of the situtation.
 	Context *Context
+
+	// metadata contains typed objects attached by WithMetadata(), which are
+	// not rendered by default. It's kept behind pointer, so Karma stays
+	// comparable.
+	metadata *metadata
+
+	// SourceLocation is a location of code which created message, it is set
+	// by FormatHere().
+	SourceLocation *SourceLocation
+
+	// Category is a semantic category of error, it is set by
+	// WithCategory().
+	Category Category
 }
 
 // Hierarchical represents interface, which methods will be used instead
@@ -105,31 +133,330 @@ type Hierarchical interface {
 // assert that instance of Karma implements Hierarchical interface
 var _ Hierarchical = (*Karma)(nil)
 
+// Coder represents error, which has machine-readable code. Errors with the
+// same non-empty codes are considered equal by Contains().
+type Coder interface {
+	// GetCode returns error code.
+	GetCode() string
+}
+
+// Severity represents severity of error.
+type Severity int
+
+const (
+	// SeverityUnknown represents unspecified severity.
+	SeverityUnknown Severity = iota
+
+	// SeverityDebug represents severity of errors useful only for debugging.
+	SeverityDebug
+
+	// SeverityInfo represents severity of informational errors.
+	SeverityInfo
+
+	// SeverityWarning represents severity of errors which can be ignored.
+	SeverityWarning
+
+	// SeverityError represents severity of regular errors.
+	SeverityError
+
+	// SeverityCritical represents severity of errors which need immediate
+	// attention.
+	SeverityCritical
+)
+
+// String returns string representation of severity.
+func (severity Severity) String() string {
+	switch severity {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
 // Reason is either `error` or string.
 type Reason interface{}
 
 type jsonRepresentation struct {
-	Reason  json.RawMessage `json:"reason,omitempty"`
-	Message string          `json:"message,omitempty"`
-	Context *Context        `json:"context,omitempty"`
+	Reason     json.RawMessage `json:"reason,omitempty"`
+	Message    string          `json:"message,omitempty"`
+	Context    *Context        `json:"context,omitempty"`
+	AppVersion string          `json:"app_version,omitempty"`
+
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	SourceLocation *SourceLocation `json:"source_location,omitempty"`
+
+	Category Category `json:"category,omitempty"`
+}
+
+// joinedError represents error which wraps multiple errors, e.g. result of
+// errors.Join().
+type joinedError interface {
+	Unwrap() []error
 }
 
 // Format creates new hierarchical message.
 //
 // With reason == nil call will be equal to `fmt.Errorf()`.
+//
+// If reason wraps multiple errors, like errors.Join() result, each of them
+// will become separate branch.
 func Format(
 	reason Reason,
 	message string,
 	args ...interface{},
 ) Karma {
-	return Karma{
-		Message: fmt.Sprintf(message, args...),
-		Reason:  reason,
+	return format(nil, reason, message, args)
+}
+
+func format(
+	context *Context,
+	reason Reason,
+	message string,
+	args []interface{},
+) Karma {
+	return formatWithDefaults(
+		context,
+		reason,
+		message,
+		args,
+		GetDefaultContext(nil),
+	)
+}
+
+func formatWithDefaults(
+	context *Context,
+	reason Reason,
+	message string,
+	args []interface{},
+	defaults *Context,
+	options ...FormatOption,
+) Karma {
+	recordFormat(context, reason, message)
+
+	message, context = formatMessage(context, message, args)
+
+	reason, context = unwrapFmtError(reason, context)
+
+	if message == "" {
+		// lift message of nested karma instead of producing level without
+		// message, all other fields of nested karma are kept as is; context
+		// of nested karma already contains pairs extracted from its reason,
+		// so extractors are not run again
+		if karma, ok := getKarma(reason); ok {
+			inner := *karma
+
+			message = inner.Message
+			reason = inner.Reason
+			context = appendContext(inner.Context, context)
+
+			lifted := context
+
+			options = append([]FormatOption{func(karma *Karma) {
+				*karma = inner
+				karma.Context = lifted
+			}}, options...)
+		}
+	}
+
+	return newKarma(context, reason, message, defaults, options...)
+}
+
+func newKarma(
+	context *Context,
+	reason Reason,
+	message string,
+	defaults *Context,
+	options ...FormatOption,
+) Karma {
+	karma := Karma{
+		Message: message,
+		Reason:  expandReason(reason),
+		Context: appendContext(context, extractErrorTypeContext(reason)),
+	}
+
+	// fast path: karma is not moved to heap unless options, default context
+	// or sink are used
+	if len(options) == 0 && defaults == nil && globalSink.Load() == nil {
+		return karma
+	}
+
+	return configureKarma(karma, reason, defaults, options)
+}
+
+// configureKarma applies options and default context to given message and
+// captures result to the global sink.
+func configureKarma(
+	karma Karma,
+	reason Reason,
+	defaults *Context,
+	options []FormatOption,
+) Karma {
+	for _, option := range options {
+		option(&karma)
+	}
+
+	karma.Context = mergeDefaultContext(karma.Context, reason, defaults)
+
+	captureToSink(karma)
+
+	return karma
+}
+
+// FmtWrapKey is a context key which is used to store message of error
+// created by fmt.Errorf() with %w verb, which was passed as reason.
+const FmtWrapKey = "fmt_wrap"
+
+// unwrapFmtError replaces error created by fmt.Errorf() with %w verb with
+// wrapped error and adds message of fmt.Errorf() error to the context, so
+// wrapped error is not rendered twice.
+func unwrapFmtError(reason Reason, context *Context) (Reason, *Context) {
+	wrapper, ok := reason.(interface {
+		error
+		Unwrap() error
+	})
+	if !ok {
+		return reason, context
+	}
+
+	kind := reflect.TypeOf(wrapper)
+	if kind.Kind() != reflect.Ptr || kind.Elem().PkgPath() != "fmt" {
+		return reason, context
+	}
+
+	inner := wrapper.Unwrap()
+	if inner == nil {
+		return reason, context
+	}
+
+	return inner, context.Describe(FmtWrapKey, wrapper.Error())
+}
+
+func expandReason(reason Reason) Reason {
+	if _, ok := getKarma(reason); ok {
+		return reason
+	}
+
+	if _, ok := reason.(*flattened); ok {
+		return reason
+	}
+
+	joined, ok := reason.(joinedError)
+	if !ok {
+		return reason
+	}
+
+	reasons := []Reason{}
+	for _, err := range joined.Unwrap() {
+		if err != nil {
+			reasons = append(reasons, err)
+		}
+	}
+
+	if len(reasons) == 0 {
+		return reason
+	}
+
+	return joinReasons(reasons)
+}
+
+// joinReasons returns reason which can be used as Karma.Reason for given list
+// of reasons: nil, single reason or []Reason.
+func joinReasons(reasons []Reason) Reason {
+	switch len(reasons) {
+	case 0:
+		return nil
+	case 1:
+		return reasons[0]
+	default:
+		return reasons
+	}
+}
+
+// FormatOrNil creates new hierarchical message same as Format(), but returns
+// nil if both reason and formatted message are empty.
+func FormatOrNil(
+	reason Reason,
+	message string,
+	args ...interface{},
+) error {
+	if isEmptyReason(reason) && fmt.Sprintf(message, args...) == "" {
+		return nil
+	}
+
+	return Format(reason, message, args...)
+}
+
+// FormatIf creates new hierarchical message same as Format() if condition is
+// true, otherwise reason is returned as error as is. Reasons which are not
+// errors are converted to errors, empty reasons are returned as nil.
+func FormatIf(
+	condition bool,
+	reason Reason,
+	message string,
+	args ...interface{},
+) error {
+	if condition {
+		return Format(reason, message, args...)
+	}
+
+	if isEmptyReason(reason) {
+		return nil
+	}
+
+	if err, ok := reason.(error); ok {
+		return err
+	}
+
+	return errors.New(stringReason(reason))
+}
+
+// IsEmpty returns true if given error is nil or is Karma without both
+// message and reason, which is meaningless and should be treated as nil.
+func IsEmpty(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	if pointer, ok := err.(*Karma); ok && pointer == nil {
+		return true
+	}
+
+	karma, ok := getKarma(err)
+
+	return ok && karma.Message == "" && karma.Reason == nil
+}
+
+func isEmptyReason(reason Reason) bool {
+	switch typed := reason.(type) {
+	case nil:
+		return true
+	case string:
+		return typed == ""
+	case []byte:
+		return len(typed) == 0
+	case Karma:
+		return typed.Message == "" && isEmptyReason(typed.Reason)
+	case *Karma:
+		return typed == nil ||
+			(typed.Message == "" && isEmptyReason(typed.Reason))
+	default:
+		return false
 	}
 }
 
 // ContextValueFormatter returns string representation of context value when
-// Format() is called on Karma struct.
+// Format() is called on Karma struct. Durations are formatted as "500ms" and
+// times are formatted as RFC3339. If it's set to nil, fmt.Sprint() is used.
 var ContextValueFormatter = func(value interface{}) string {
 	if value, ok := value.(string); ok {
 		if value == "" {
@@ -140,6 +467,19 @@ var ContextValueFormatter = func(value interface{}) string {
 	switch value := value.(type) {
 	case string:
 		return value
+	case time.Duration:
+		return value.String()
+	case time.Time:
+		return value.Format(time.RFC3339)
+	case json.RawMessage:
+		var result bytes.Buffer
+
+		err := json.Indent(&result, value, "", "  ")
+		if err != nil {
+			return string(value)
+		}
+
+		return result.String()
 	case fmt.Stringer:
 		return fmt.Sprint(value)
 	case bool:
@@ -158,15 +498,104 @@ var ContextValueFormatter = func(value interface{}) string {
 	}
 }
 
+var contextValueFormatters = struct {
+	sync.RWMutex
+	byKey map[string]func(interface{}) string
+}{
+	byKey: map[string]func(interface{}) string{},
+}
+
+// RegisterContextValueFormatter sets formatter, which will be used instead of
+// ContextValueFormatter for context values with specified key.
+func RegisterContextValueFormatter(key string, fn func(interface{}) string) {
+	contextValueFormatters.Lock()
+	defer contextValueFormatters.Unlock()
+
+	contextValueFormatters.byKey[key] = fn
+}
+
+// UnregisterContextValueFormatter removes formatter previously registered for
+// specified key by RegisterContextValueFormatter.
+func UnregisterContextValueFormatter(key string) {
+	contextValueFormatters.Lock()
+	defer contextValueFormatters.Unlock()
+
+	delete(contextValueFormatters.byKey, key)
+}
+
+func formatContextValue(key string, value interface{}) string {
+	if pii, ok := value.(piiValue); ok {
+		if RedactPII {
+			return redactedPII
+		}
+
+		value = pii.value
+	}
+
+	contextValueFormatters.RLock()
+	formatter, ok := contextValueFormatters.byKey[key]
+	contextValueFormatters.RUnlock()
+
+	if ok {
+		return formatter(value)
+	}
+
+	if ContextValueFormatter == nil {
+		return fmt.Sprint(value)
+	}
+
+	return ContextValueFormatter(value)
+}
+
 // Karma returns hierarchical string representation. If no nested
 // message was specified, then only current message will be returned.
+//
+// Representation is produced by DefaultRenderer.
 func (karma Karma) String() string {
+	renderer := DefaultRenderer
+	if _, ok := renderer.(TreeRenderer); ok || renderer == nil {
+		return renderTree(karma)
+	}
+
+	var buffer bytes.Buffer
+
+	err := renderer.Render(&buffer, karma)
+	if err != nil {
+		return renderTree(karma)
+	}
+
+	return buffer.String()
+}
+
+// renderTree returns hierarchical tree representation of message, see
+// TreeRenderer.
+func renderTree(karma Karma) string {
+	if DefaultRenderConfig.DeduplicateMessages {
+		karma = deduplicateMessages(karma)
+	}
+
+	metadata := karma.metadata.get()
+
+	if karma.SourceLocation != nil {
+		karma.Message += " [" + karma.SourceLocation.String() + "]"
+	}
+
 	karma.Context.Walk(func(name string, value interface{}) {
 		karma = Push(karma, Push(
-			name+": "+ContextValueFormatter(value),
+			name+": "+truncateContextValue(formatContextValue(name, value)),
 		))
 	})
 
+	if DefaultRenderConfig.IncludeMetadata {
+		for _, key := range sortedKeys(metadata) {
+			karma = Push(karma, Push(
+				key+": "+truncateContextValue(
+					formatContextValue(key, metadata[key]),
+				),
+			))
+		}
+	}
+
 	switch value := karma.Reason.(type) {
 	case nil:
 		return karma.Message
@@ -178,7 +607,7 @@ func (karma Karma) String() string {
 		return karma.Message + "\n" +
 			BranchDelimiter +
 			strings.Replace(
-				stringReason(karma.Reason),
+				renderReason(karma.Reason),
 				"\n",
 				"\n"+getBranchIndentation(),
 				-1,
@@ -193,6 +622,17 @@ func getBranchIndentation() string {
 	return branchIndentation
 }
 
+// renderReason returns tree representation of nested hierarchical message
+// regardless of DefaultRenderer, other reasons are converted by
+// stringReason().
+func renderReason(reason Reason) string {
+	if karma, ok := getKarma(reason); ok {
+		return renderTree(*karma)
+	}
+
+	return stringReason(reason)
+}
+
 func stringReason(reason Reason) string {
 	switch typed := reason.(type) {
 	case []byte:
@@ -209,6 +649,34 @@ func stringReason(reason Reason) string {
 	}
 }
 
+// NewReason converts given value to Reason. Errors and strings are returned
+// as is, byte slices are wrapped into type which implements fmt.Stringer and
+// any other values are converted to string using fmt.Sprint().
+func NewReason(value interface{}) Reason {
+	switch typed := value.(type) {
+	case nil:
+		return nil
+
+	case error:
+		return typed
+
+	case string:
+		return typed
+
+	case []byte:
+		return bytesReason(typed)
+
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+type bytesReason []byte
+
+func (reason bytesReason) String() string {
+	return string(reason)
+}
+
 // GetReasons returns nested messages
 func GetReasons(err error) []Reason {
 	if karma, ok := getKarma(err); ok {
@@ -218,6 +686,40 @@ func GetReasons(err error) []Reason {
 	return []Reason{err}
 }
 
+// Cause returns root cause of given error, which is the first reason
+// in the chain which is not hierarchical itself. It is analogous to
+// pkg/errors.Cause().
+func Cause(err error) error {
+	if IsEmpty(err) {
+		return nil
+	}
+
+	var reason Reason = err
+
+	for {
+		hierarchical, ok := reason.(Hierarchical)
+		if !ok {
+			break
+		}
+
+		reasons := hierarchical.GetReasons()
+		if len(reasons) == 0 {
+			break
+		}
+
+		reason = reasons[0]
+	}
+
+	switch typed := reason.(type) {
+	case nil:
+		return nil
+	case error:
+		return typed
+	default:
+		return errors.New(stringReason(typed))
+	}
+}
+
 // Error implements error interface, Karma can be returned as error.
 func (karma Karma) Error() string {
 	return karma.String()
@@ -245,9 +747,80 @@ func (karma Karma) GetMessage() string {
 	}
 }
 
+// GetMessages returns messages of all levels of hierarchy in depth-first
+// order, starting from current level. Empty messages are omitted.
+func (karma Karma) GetMessages() []string {
+	return GetMessages(karma)
+}
+
+// GetMessages returns messages of all levels of hierarchy of given error.
+// See Karma.GetMessages() for details.
+func GetMessages(err error) []string {
+	var messages []string
+
+	Walk(err, func(_ int, reason Reason, _ *Context) {
+		var message string
+
+		if karma, ok := getKarma(reason); ok {
+			message = karma.Message
+		} else {
+			switch reason := reason.(type) {
+			case Hierarchical:
+				message = reason.GetMessage()
+			case joinedError:
+				// errors are visited separately
+			default:
+				message = stringReason(reason)
+			}
+		}
+
+		if message != "" {
+			messages = append(messages, message)
+		}
+	})
+
+	return messages
+}
+
 // GetContext returns context
 func (karma Karma) GetContext() *Context {
-	return karma.Context
+	return karma.Context.resolve()
+}
+
+// GetMergedContext returns context pairs of all levels of hierarchy merged
+// into single context in depth-first order, starting from top-level. Pairs
+// with the same keys are preserved.
+func (karma Karma) GetMergedContext() *Context {
+	var result *Context
+
+	Walk(karma, func(_ int, _ Reason, context *Context) {
+		result = appendContext(result, context)
+	})
+
+	return result
+}
+
+// GetContextAt returns context pairs of hierarchy levels with specified depth,
+// depth of top-level is 0. If there are several levels with the same depth,
+// their contexts are merged in depth-first order.
+func (karma Karma) GetContextAt(depth int) *Context {
+	var result *Context
+
+	Walk(karma, func(level int, _ Reason, context *Context) {
+		if level == depth {
+			result = appendContext(result, context)
+		}
+	})
+
+	return result
+}
+
+// Annotate returns copy of message with specified key-value pair added to
+// its context. No new nesting level is created.
+func (karma Karma) Annotate(key string, value interface{}) Karma {
+	karma.Context = karma.Context.Describe(key, value)
+
+	return karma
 }
 
 // Descend calls specified callback for every nested hierarchical message.
@@ -258,11 +831,18 @@ func (karma Karma) Descend(callback func(Reason)) {
 		return
 	}
 
-	for _, reason := range karma.GetReasons() {
+	descend(karma.GetReasons(), callback)
+}
+
+func descend(reasons []Reason, callback func(Reason)) {
+	for _, reason := range reasons {
 		switch reason := reason.(type) {
 		case Karma:
 			callback(reason)
 			reason.Descend(callback)
+		case Hierarchical:
+			callback(reason)
+			descend(reason.GetReasons(), callback)
 		default:
 			callback(reason)
 		}
@@ -271,20 +851,24 @@ func (karma Karma) Descend(callback func(Reason)) {
 
 func (karma Karma) MarshalJSON() ([]byte, error) {
 	result := jsonRepresentation{
-		Message: karma.Message,
-		Context: karma.Context,
+		Message:        karma.Message,
+		Context:        karma.Context,
+		SourceLocation: karma.SourceLocation,
+		Category:       karma.Category,
 	}
 
-	var err error
+	if version, ok := karma.Context.lookup(AppVersionKey); ok {
+		result.AppVersion = fmt.Sprint(version)
+		result.Context = karma.Context.without(AppVersionKey)
+	}
 
-	switch reason := karma.Reason.(type) {
-	case json.Marshaler:
-		result.Reason, err = json.Marshal(reason)
-	case error:
-		result.Reason, err = json.Marshal(reason.Error())
-	default:
-		result.Reason, err = json.Marshal(reason)
+	if DefaultRenderConfig.IncludeMetadata {
+		result.Metadata = karma.metadata.get()
 	}
+
+	var err error
+
+	result.Reason, err = marshalReason(karma.Reason)
 	if err != nil {
 		return nil, err
 	}
@@ -292,6 +876,31 @@ func (karma Karma) MarshalJSON() ([]byte, error) {
 	return json.Marshal(result)
 }
 
+// marshalReason encodes reason as JSON, errors which are not json.Marshaler
+// are encoded as their messages, including errors in list of reasons.
+func marshalReason(reason Reason) ([]byte, error) {
+	switch reason := reason.(type) {
+	case json.Marshaler:
+		return json.Marshal(reason)
+	case error:
+		return json.Marshal(reason.Error())
+	case []Reason:
+		reasons := make([]json.RawMessage, len(reason))
+		for index, nested := range reason {
+			data, err := marshalReason(nested)
+			if err != nil {
+				return nil, err
+			}
+
+			reasons[index] = data
+		}
+
+		return json.Marshal(reasons)
+	default:
+		return json.Marshal(reason)
+	}
+}
+
 func (karma *Karma) UnmarshalJSON(data []byte) error {
 	var container jsonRepresentation
 
@@ -302,7 +911,7 @@ func (karma *Karma) UnmarshalJSON(data []byte) error {
 
 	var reason Karma
 
-	if len(container.Reason) > 0 {
+	if len(container.Reason) > 0 && string(container.Reason) != "null" {
 		err = json.Unmarshal(container.Reason, &reason)
 		if err != nil {
 			err = json.Unmarshal(container.Reason, &karma.Reason)
@@ -316,15 +925,47 @@ func (karma *Karma) UnmarshalJSON(data []byte) error {
 
 	karma.Message = container.Message
 	karma.Context = container.Context
+	if len(container.Metadata) > 0 {
+		karma.metadata = &metadata{values: container.Metadata}
+	}
+	karma.SourceLocation = container.SourceLocation
+	karma.Category = container.Category
+
+	if container.AppVersion != "" {
+		karma.Context = karma.Context.Describe(
+			AppVersionKey,
+			container.AppVersion,
+		)
+	}
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, returns hierarchical string
+// representation.
+func (karma Karma) MarshalText() ([]byte, error) {
+	return []byte(karma.Error()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Since hierarchy can't be
+// restored from plain text, whole text is stored as message.
+func (karma *Karma) UnmarshalText(data []byte) error {
+	*karma = Karma{
+		Message: string(data),
+	}
 
 	return nil
 }
 
 // Push creates new hierarchy message with multiple branches separated by
 // separator, delimited by delimiter and prolongated by prolongator.
+//
+// If reason is Karma, reasons are added to its reasons, otherwise reason is
+// used as message. Nil reason is treated as empty Karma without message. Nil
+// reasons are skipped.
 func Push(reason Reason, reasons ...Reason) Karma {
 	parent, ok := reason.(Karma)
-	if !ok {
+	if !ok && reason != nil {
 		parent = Karma{
 			Message: fmt.Sprint(reason),
 		}
@@ -338,69 +979,191 @@ func Push(reason Reason, reasons ...Reason) Karma {
 		}
 	}
 
+	if len(newReasons) == 0 {
+		return Karma{
+			Message: parent.Message,
+		}
+	}
+
 	return Karma{
 		Message: parent.Message,
 		Reason:  newReasons,
 	}
 }
 
+// NewContext creates new empty context list. Unlike nil context list, it's
+// not nil, but it can be used same way.
+func NewContext() *Context {
+	return &Context{}
+}
+
+// PushNonNil is the same as Push(), but first non-nil reason is used as
+// parent for the rest of reasons. Empty Karma is returned if all reasons are
+// nil.
+func PushNonNil(reasons ...Reason) Karma {
+	nonNil := []Reason{}
+	for _, reason := range reasons {
+		if reason != nil {
+			nonNil = append(nonNil, reason)
+		}
+	}
+
+	if len(nonNil) == 0 {
+		return Karma{}
+	}
+
+	return Push(nonNil[0], nonNil[1:]...)
+}
+
 // Describe creates new context list, which can be used to produce context-rich
 // hierarchical message.
 func Describe(key string, value interface{}) *Context {
 	return &Context{
 		KeyValue: KeyValue{
-			Key:   key,
+			Key:   internKey(key),
 			Value: value,
 		},
 	}
 }
 
+// DescribeMany creates new context list from alternating keys and values,
+// which is the same as sequence of Describe() calls:
+//
+//	karma.DescribeMany("host", host, "port", port).Format(err, "...")
+//
+// DescribeMany panics if number of arguments is odd or key is not a string.
+func DescribeMany(kvpairs ...interface{}) *Context {
+	if len(kvpairs)%2 != 0 {
+		panic(fmt.Sprintf(
+			"karma: odd number of key-value arguments: %d",
+			len(kvpairs),
+		))
+	}
+
+	var context *Context
+
+	for i := 0; i < len(kvpairs); i += 2 {
+		key, ok := kvpairs[i].(string)
+		if !ok {
+			panic(fmt.Sprintf(
+				"karma: key at position %d is %T, not string",
+				i, kvpairs[i],
+			))
+		}
+
+		context = context.Describe(key, kvpairs[i+1])
+	}
+
+	return context
+}
+
+// DescribeTyped creates new context list same as Describe, but accepts key of
+// any comparable type, e.g. typed enum. Key will be converted to string using
+// fmt.Sprint(), so it will be rendered and marshaled to JSON as string.
+func DescribeTyped[K comparable](key K, value interface{}) *Context {
+	return Describe(fmt.Sprint(key), value)
+}
+
+// DescribeJSON creates new context list with raw JSON value, which will be
+// inlined as is during JSON marshaling and pretty-printed during rendering.
+// If given data is not valid JSON, it will be used as string value.
+func DescribeJSON(key string, rawJSON []byte) *Context {
+	return Describe(key, jsonValue(rawJSON))
+}
+
+// DescribeError creates new context list with message of given error as
+// value. If error is hierarchical, only its top-level message is used, use
+// DescribeErrorFull to use whole string representation instead. Nil error is
+// described as nil value.
+func DescribeError(key string, err error) *Context {
+	if karma, ok := getKarma(err); ok {
+		return Describe(key, karma.GetMessage())
+	}
+
+	return DescribeErrorFull(key, err)
+}
+
+// DescribeErrorFull creates new context list with result of Error() of given
+// error as value.
+func DescribeErrorFull(key string, err error) *Context {
+	if err == nil {
+		return Describe(key, nil)
+	}
+
+	return Describe(key, err.Error())
+}
+
+func jsonValue(rawJSON []byte) interface{} {
+	if !json.Valid(rawJSON) {
+		return string(rawJSON)
+	}
+
+	return json.RawMessage(rawJSON)
+}
+
 // Find typed object in given chain of reasons, returns true if reason with the
 // same type found, if typed object is addressable, value will be stored in it.
 func Find(err Reason, typed interface{}) bool {
 	indirect := reflect.Indirect(reflect.ValueOf(typed))
 	indirectType := indirect.Type()
 
-	karma, ok := getKarma(err)
-	if ok {
-		return find(karma, typed, indirect, indirectType)
-	}
+	found := false
+
+	walk(err, 0, func(_ int, reason Reason, _ *Context) bool {
+		if _, ok := getKarma(reason); ok {
+			return true
+		}
+
+		if reflect.TypeOf(reason) != indirectType {
+			return true
+		}
 
-	same := reflect.TypeOf(err) == indirectType
-	if same {
 		if indirect.CanAddr() {
-			indirect.Set(reflect.ValueOf(err))
+			indirect.Set(reflect.ValueOf(reason))
 		}
+
+		found = true
+
+		return false
+	})
+
+	return found
+}
+
+// GetReasonByType returns first reason in the hierarchy, which can be
+// asserted to type T. Unlike Find(), it does not use reflection.
+func GetReasonByType[T any](err error) (T, bool) {
+	if err == nil {
+		var zero T
+		return zero, false
 	}
 
-	return same
+	return getReasonByType[T](GetReasons(err))
 }
 
-func find(
-	karma *Karma,
-	typed interface{},
-	indirect reflect.Value,
-	indirectType reflect.Type,
-) bool {
-	for _, nested := range karma.GetReasons() {
-		subkarma, ok := getKarma(nested)
-		if ok {
-			if find(subkarma, typed, indirect, indirectType) {
-				return true
-			}
-		} else {
-			same := reflect.TypeOf(nested) == indirectType
-			if same {
-				if indirect.CanAddr() {
-					indirect.Set(reflect.ValueOf(nested))
-				}
+func getReasonByType[T any](reasons []Reason) (T, bool) {
+	for _, reason := range reasons {
+		if typed, ok := reason.(T); ok {
+			return typed, true
+		}
+
+		var nested []Reason
+
+		if karma, ok := getKarma(reason); ok {
+			nested = karma.GetReasons()
+		} else if joined, ok := reason.(joinedError); ok {
+			for _, err := range joined.Unwrap() {
+				nested = append(nested, err)
 			}
+		}
 
-			return same
+		if typed, ok := getReasonByType[T](nested); ok {
+			return typed, true
 		}
 	}
 
-	return false
+	var zero T
+	return zero, false
 }
 
 // Contains returns true when branch is found in reasons of given chain. Or
@@ -408,32 +1171,103 @@ func find(
 // Useful when you work with result of multi-level error and just wanted to
 // check that error contains os.ErrNoExist.
 func Contains(chain Reason, branch Reason) bool {
-	karma, ok := getKarma(chain)
-	if ok {
-		return contains(karma, branch)
-	}
+	_, _, ok := ContainsAt(chain, branch)
 
-	return stringReason(chain) == stringReason(branch)
+	return ok
 }
 
-func contains(karma *Karma, reason Reason) bool {
-	reasonString := fmt.Sprint(reason)
-	for _, nested := range karma.GetReasons() {
-		subkarma, ok := getKarma(nested)
-		if ok {
-			if contains(subkarma, reason) {
-				return true
-			}
+// ContainsAt is the same as Contains(), but also returns found reason and
+// path of reason indices from chain to it, e.g. [0, 2] means third reason of
+// the first reason of chain. Path is empty if chain itself matches branch.
+func ContainsAt(chain Reason, branch Reason) (Reason, []int, bool) {
+	branchString := stringReason(branch)
+
+	var (
+		found Reason
+		at    []int
+		ok    bool
+	)
+
+	walkPath(chain, nil, func(path []int, reason Reason, _ *Context) bool {
+		if karma, isKarma := getKarma(reason); isKarma {
+			ok = len(path) > 0 && sameCode(*karma, branch)
 		} else {
-			if fmt.Sprint(nested) == reasonString {
-				return true
-			}
+			ok = stringReason(reason) == branchString ||
+				sameCode(reason, branch)
+		}
+
+		if ok {
+			found = reason
+			at = append([]int{}, path...)
+		}
+
+		return !ok
+	})
+
+	return found, at, ok
+}
+
+// ContainsAll returns true when every of given branches is found in chain.
+// See Contains() for details.
+func ContainsAll(chain Reason, branches ...Reason) bool {
+	for _, branch := range branches {
+		if !Contains(chain, branch) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ContainsAny returns true when at least one of given branches is found in
+// chain. See Contains() for details.
+func ContainsAny(chain Reason, branches ...Reason) bool {
+	for _, branch := range branches {
+		if Contains(chain, branch) {
+			return true
 		}
 	}
 
 	return false
 }
 
+func sameCode(reason Reason, branch Reason) bool {
+	reasonCoder, ok := reason.(Coder)
+	if !ok {
+		return false
+	}
+
+	branchCoder, ok := branch.(Coder)
+	if !ok {
+		return false
+	}
+
+	code := reasonCoder.GetCode()
+
+	return code != "" && code == branchCoder.GetCode()
+}
+
+// GetKarma returns hierarchical message if given error is Karma or *Karma.
+func GetKarma(err error) (Karma, bool) {
+	karma, ok := getKarma(err)
+	if !ok {
+		return Karma{}, false
+	}
+
+	return *karma, true
+}
+
+// MustGetKarma returns hierarchical message if given error is Karma or *Karma
+// and panics otherwise.
+func MustGetKarma(err error) Karma {
+	karma, ok := getKarma(err)
+	if !ok {
+		panic(fmt.Sprintf("karma: %T is not karma.Karma", err))
+	}
+
+	return *karma
+}
+
 func getKarma(reason Reason) (*Karma, bool) {
 	karma, ok := reason.(Karma)
 	if ok {
@@ -441,7 +1275,7 @@ func getKarma(reason Reason) (*Karma, bool) {
 	}
 
 	pointer, ok := reason.(*Karma)
-	if ok {
+	if ok && pointer != nil {
 		return pointer, true
 	}
 
@@ -491,7 +1325,7 @@ func formatReasons(karma Karma, reasons []Reason) string {
 			message.WriteString(splitter)
 		}
 
-		reason := stringReason(reason)
+		reason := renderReason(reason)
 
 		message.WriteString(strings.Replace(
 			reason,
@@ -533,7 +1367,17 @@ func (karma Karma) Is(target error) bool {
 	return Contains(karma, target)
 }
 
+// Unwrap returns reason of message if it is single error, so errors.Unwrap()
+// works same way as with fmt.Errorf() and %w verb. Otherwise, error, which
+// unwraps into all nested errors is returned, or nil if there is no reason.
 func (karma Karma) Unwrap() error {
+	switch reason := karma.Reason.(type) {
+	case nil:
+		return nil
+	case error:
+		return reason
+	}
+
 	return weirdo{k: karma}
 }
 
@@ -0,0 +1,31 @@
+//go:build go1.23
+
+package karma
+
+import "iter"
+
+// Reasons returns iterator over index and reason pairs of direct reasons.
+func (karma Karma) Reasons() iter.Seq2[int, Reason] {
+	return func(yield func(int, Reason) bool) {
+		for index, reason := range karma.GetReasons() {
+			if !yield(index, reason) {
+				return
+			}
+		}
+	}
+}
+
+// All returns iterator over depth and reason pairs of the whole reason tree
+// in depth-first order, like Walk() does. Message itself is not yielded, so
+// depth of direct reasons is 1.
+func (karma Karma) All() iter.Seq2[int, Reason] {
+	return func(yield func(int, Reason) bool) {
+		walk(karma, 0, func(depth int, reason Reason, _ *Context) bool {
+			if depth == 0 {
+				return true
+			}
+
+			return yield(depth, reason)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+//go:build go1.23
+
+package karma
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKarma_Reasons_IteratesOverDirectReasons(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		[]Reason{errors.New("first"), Format("deep", "second")},
+		"top",
+	)
+
+	reasons := []string{}
+	for index, reason := range err.Reasons() {
+		reasons = append(reasons, fmt.Sprintf("%d %s", index, reason))
+	}
+
+	test.Equal([]string{"0 first", "1 second\n└─ deep"}, reasons)
+}
+
+func TestKarma_All_IteratesOverWholeTree(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		[]Reason{errors.New("first"), Format("deep", "second")},
+		"top",
+	)
+
+	depths := []int{}
+	for depth := range err.All() {
+		depths = append(depths, depth)
+	}
+
+	test.Equal([]int{1, 1, 2}, depths)
+
+	depths = nil
+	for depth := range err.All() {
+		depths = append(depths, depth)
+		break
+	}
+
+	test.Equal([]int{1}, depths)
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -55,6 +56,16 @@ func TestFormat_CanFormatErrorWithSimpleReasonAndArgs(t *testing.T) {
 	)
 }
 
+func TestFormat_AllocatesOnlyMessageByDefault(t *testing.T) {
+	test := assert.New(t)
+
+	reason := errors.New("reason")
+
+	test.Equal(1.0, testing.AllocsPerRun(100, func() {
+		_ = Format(reason, "message")
+	}))
+}
+
 func TestFormat_CanFormatHierarchicalReason(t *testing.T) {
 	test := assert.New(t)
 
@@ -93,6 +104,42 @@ func TestFormat_CanFormatAnyReason(t *testing.T) {
 	)
 }
 
+func TestFormatOrNil_ReturnsNilForEmptyMessage(t *testing.T) {
+	test := assert.New(t)
+
+	test.NoError(FormatOrNil(nil, ""))
+	test.NoError(FormatOrNil("", ""))
+	test.NoError(FormatOrNil(Karma{}, ""))
+}
+
+func TestFormatOrNil_DoesNotCaptureNilResult(t *testing.T) {
+	test := assert.New(t)
+
+	sink := NewSink()
+
+	SetSink(sink)
+	defer SetSink(nil)
+
+	test.NoError(FormatOrNil(nil, ""))
+	test.Empty(sink.Errors())
+
+	err := FormatOrNil(nil, "message")
+	test.Equal([]Karma{err.(Karma)}, sink.Errors())
+}
+
+func TestFormatOrNil_ReturnsErrorForNonEmptyMessage(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(FormatOrNil(nil, "integer: %d", 9), "integer: 9")
+	test.EqualError(
+		FormatOrNil(errors.New("reason"), ""),
+		output(
+			"",
+			"└─ reason",
+		),
+	)
+}
+
 func TestCanSetBranchDelimiter(t *testing.T) {
 	test := assert.New(t)
 
@@ -190,7 +237,6 @@ func TestCanMarshalErrorsToJSON(t *testing.T) {
 }
 
 func TestCanUnmarshalFromJSON(t *testing.T) {
-	return
 	test := assert.New(t)
 
 	input := `{
@@ -257,6 +303,49 @@ func TestCanUnmarshalNestedReasonFromJSON(t *testing.T) {
 	)
 }
 
+func TestCanUnmarshalNullReasonFromJSON(t *testing.T) {
+	test := assert.New(t)
+
+	var actual Karma
+
+	err := json.Unmarshal(
+		[]byte(`{"reason": null, "message": "unable to connect"}`),
+		&actual,
+	)
+	test.NoError(err)
+
+	test.Nil(actual.Reason)
+	test.Equal(Format(nil, "unable to connect"), actual)
+	test.EqualError(actual, "unable to connect")
+}
+
+func TestCanMarshalToText(t *testing.T) {
+	test := assert.New(t)
+
+	text, err := Describe("host", "example.com").Format(
+		errors.New("access denied"),
+		"unable to connect",
+	).MarshalText()
+	test.NoError(err)
+	test.Equal(
+		output(
+			"unable to connect",
+			"├─ access denied",
+			"└─ host: example.com",
+		),
+		string(text),
+	)
+}
+
+func TestCanUnmarshalFromText(t *testing.T) {
+	test := assert.New(t)
+
+	actual := Format(errors.New("reason"), "message")
+
+	test.NoError(actual.UnmarshalText([]byte("unable to connect\n└─ EOF")))
+	test.Equal(Karma{Message: "unable to connect\n└─ EOF"}, actual)
+}
+
 func TestContext_CanAddMultipleKeyValues(t *testing.T) {
 	test := assert.New(t)
 
@@ -450,6 +539,75 @@ func TestContext_CanOperateOnNilContext(t *testing.T) {
 	)
 }
 
+func TestDescribeJSON_PrettyPrintsValue(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		DescribeJSON("body", []byte(`{"error":"denied","code":403}`)).
+			DescribeJSON("invalid", []byte(`{invalid`)).
+			Format(nil, "request failed"),
+		output(
+			"request failed",
+			"├─ body: {",
+			`│    "error": "denied",`,
+			`│    "code": 403`,
+			"│  }",
+			"└─ invalid: {invalid",
+		),
+	)
+}
+
+func TestDescribeJSON_InlinesValueInJSON(t *testing.T) {
+	test := assert.New(t)
+
+	test.JSONEq(
+		`{"reason":null,"message":"request failed","context":[`+
+			`{"key":"body","value":{"error":"denied","code":403}},`+
+			`{"key":"invalid","value":"{invalid"}]}`,
+		JSON(
+			DescribeJSON("body", []byte(`{"error":"denied","code":403}`)).
+				DescribeJSON("invalid", []byte(`{invalid`)).
+				Format(nil, "request failed"),
+		),
+	)
+}
+
+func TestContext_CanPrependKeyValue(t *testing.T) {
+	test := assert.New(t)
+
+	context := Describe("host", "example.com").Describe("port", 80)
+
+	test.EqualError(
+		context.Prepend("request_id", "abc").Format(nil, "unable to connect"),
+		output(
+			"unable to connect",
+			"├─ request_id: abc",
+			"├─ host: example.com",
+			"└─ port: 80",
+		),
+	)
+
+	test.EqualError(
+		context.Describe("scheme", "http").Format(nil, "unable to connect"),
+		output(
+			"unable to connect",
+			"├─ host: example.com",
+			"├─ port: 80",
+			"└─ scheme: http",
+		),
+	)
+
+	var void *Context
+
+	test.EqualError(
+		void.Prepend("request_id", "abc").Format(nil, "unable to connect"),
+		output(
+			"unable to connect",
+			"└─ request_id: abc",
+		),
+	)
+}
+
 func TestContains_ReturnsTrueWhenFoundSameError(t *testing.T) {
 	test := assert.New(t)
 
@@ -507,6 +665,121 @@ func TestContains_ReturnsFalseWhenNotFoundPredefinedError(t *testing.T) {
 	test.False(Contains(err2, os.ErrInvalid))
 }
 
+func TestCause_ReturnsRootReason(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		Describe("path", "/etc/hosts").Format(os.ErrNotExist, "unable to open"),
+		"unable to resolve",
+	)
+
+	test.Equal(os.ErrNotExist, Cause(err))
+}
+
+func TestCause_ReturnsSameErrorForNonHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(os.ErrNotExist, Cause(os.ErrNotExist))
+	test.Nil(Cause(nil))
+}
+
+func TestCause_ConvertsNonErrorReasonToError(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(Cause(Format("system error", "unable to resolve")), "system error")
+}
+
+func TestCause_ReturnsHierarchicalErrorWithoutReasons(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(nil, "unable to resolve")
+
+	test.Equal(err, Cause(err))
+}
+
+// joined mimics errors.Join() result, which is not available in go1.19.
+type joined []error
+
+func joinErrors(errs ...error) error {
+	return joined(errs)
+}
+
+func (errs joined) Error() string {
+	messages := []string{}
+	for _, err := range errs {
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (errs joined) Unwrap() []error {
+	return errs
+}
+
+func TestFormat_ExpandsJoinedErrors(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		Format(
+			joinErrors(errors.New("first"), nil, errors.New("second")),
+			"multiple errors",
+		),
+		output(
+			"multiple errors",
+			"├─ first",
+			"└─ second",
+		),
+	)
+
+	test.EqualError(
+		Describe("host", "example.com").Format(
+			joinErrors(errors.New("first")),
+			"single error",
+		),
+		output(
+			"single error",
+			"├─ first",
+			"└─ host: example.com",
+		),
+	)
+}
+
+func TestContains_TraversesJoinedErrors(t *testing.T) {
+	test := assert.New(t)
+
+	joined := joinErrors(
+		errors.New("first"),
+		Format(os.ErrNotExist, "unable to open"),
+	)
+
+	test.True(Contains(joined, os.ErrNotExist))
+	test.True(Contains(Push("parent", joined), os.ErrNotExist))
+	test.False(Contains(Push("parent", joined), os.ErrInvalid))
+}
+
+func TestFind_TraversesJoinedErrors(t *testing.T) {
+	test := assert.New(t)
+
+	joined := joinErrors(
+		errors.New("first"),
+		Format(customSimpleError{"custom"}, "unable to open"),
+	)
+
+	var custom customSimpleError
+	test.True(Find(joined, &custom))
+	test.Equal("custom", custom.text)
+
+	custom = customSimpleError{}
+	test.True(Find(Push("parent", joined), &custom))
+	test.Equal("custom", custom.text)
+
+	var missing *customSimpleError
+	test.False(Find(joined, &missing))
+}
+
 func TestContext_DoesNotPanicOnFormatOnNilContext(t *testing.T) {
 	test := assert.New(t)
 
@@ -528,6 +801,39 @@ func (err customSimpleError) Error() string {
 	return err.text
 }
 
+func TestGetKarma_ReturnsHierarchicalMessage(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(io.EOF, "unable to read")
+
+	karma, ok := GetKarma(err)
+	test.True(ok)
+	test.Equal(err, karma)
+
+	karma, ok = GetKarma(&err)
+	test.True(ok)
+	test.Equal(err, karma)
+
+	test.Equal(err, MustGetKarma(err))
+}
+
+func TestGetKarma_ReturnsFalseForOtherErrors(t *testing.T) {
+	test := assert.New(t)
+
+	_, ok := GetKarma(io.EOF)
+	test.False(ok)
+
+	_, ok = GetKarma(nil)
+	test.False(ok)
+
+	_, ok = GetKarma((*Karma)(nil))
+	test.False(ok)
+
+	test.PanicsWithValue("karma: *errors.errorString is not karma.Karma", func() {
+		MustGetKarma(io.EOF)
+	})
+}
+
 func TestFind_TrueReferenceToObject(t *testing.T) {
 	test := assert.New(t)
 
@@ -588,6 +894,48 @@ func TestFind_TrueNoReferenceButEmptyTextBecauseUnaddressable(t *testing.T) {
 	test.Empty(custom.text)
 }
 
+func TestGetReasonByType_ReturnsFirstReasonOfType(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		Push(
+			"nested",
+			errors.New("first"),
+			Format(&customSimpleError{"second"}, "wrap"),
+			customSimpleError{"third"},
+		),
+		"top",
+	)
+
+	pointer, ok := GetReasonByType[*customSimpleError](err)
+	test.True(ok)
+	test.Equal("second", pointer.text)
+
+	value, ok := GetReasonByType[customSimpleError](err)
+	test.True(ok)
+	test.Equal("third", value.text)
+
+	_, ok = GetReasonByType[*os.PathError](err)
+	test.False(ok)
+}
+
+func TestGetReasonByType_ChecksNonHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	value, ok := GetReasonByType[customSimpleError](customSimpleError{"root"})
+	test.True(ok)
+	test.Equal("root", value.text)
+
+	value, ok = GetReasonByType[customSimpleError](
+		joinErrors(io.EOF, customSimpleError{"joined"}),
+	)
+	test.True(ok)
+	test.Equal("joined", value.text)
+
+	_, ok = GetReasonByType[customSimpleError](nil)
+	test.False(ok)
+}
+
 type customError struct {
 	Text   string
 	Reason error
@@ -643,6 +991,848 @@ func TestContext_Reason_DoNotPanicOnNil(t *testing.T) {
 	test.NotNil(context.Reason("zen"))
 }
 
+func TestContext_CanUseFormatterRegisteredForKey(t *testing.T) {
+	test := assert.New(t)
+
+	RegisterContextValueFormatter("size", func(value interface{}) string {
+		return fmt.Sprintf("%d bytes", value)
+	})
+	defer UnregisterContextValueFormatter("size")
+
+	test.EqualError(
+		Describe("size", 512).Describe("code", 88).Format(
+			nil,
+			"unable to write file",
+		),
+		output(
+			"unable to write file",
+			"├─ size: 512 bytes",
+			"└─ code: 88",
+		),
+	)
+
+	UnregisterContextValueFormatter("size")
+
+	test.EqualError(
+		Describe("size", 512).Format(nil, "unable to write file"),
+		output(
+			"unable to write file",
+			"└─ size: 512",
+		),
+	)
+}
+
+func TestAnnotate_AddsContextToTopLevel(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(errors.New("connection refused"), "unable to connect")
+
+	test.EqualError(
+		err.Annotate("host", "example.com").Annotate("port", 80),
+		output(
+			"unable to connect",
+			"├─ connection refused",
+			"├─ host: example.com",
+			"└─ port: 80",
+		),
+	)
+}
+
+func TestAnnotate_DoesNotChangeSelf(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(nil, "unable to connect")
+
+	_ = err.Annotate("port", 80)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"└─ host: example.com",
+		),
+	)
+}
+
+type testContextKey int
+
+func (key testContextKey) String() string {
+	return [...]string{"host", "port"}[key]
+}
+
+func TestDescribeTyped_ConvertsKeyToString(t *testing.T) {
+	test := assert.New(t)
+
+	err := DescribeTyped(testContextKey(0), "example.com").
+		Describe("port", 80).
+		Format(nil, "unable to connect")
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ host: example.com",
+			"└─ port: 80",
+		),
+	)
+
+	test.JSONEq(
+		`{"reason":null,"message":"unable to connect","context":[`+
+			`{"key":"host","value":"example.com"},`+
+			`{"key":"port","value":80}]}`,
+		JSON(err),
+	)
+
+	test.EqualError(
+		DescribeTyped(42, "answer").Format(nil, "no question"),
+		output(
+			"no question",
+			"└─ 42: answer",
+		),
+	)
+}
+
+type codedError struct {
+	message string
+	code    string
+	reasons []Reason
+}
+
+func (err codedError) Error() string {
+	return err.String()
+}
+
+func (err codedError) String() string {
+	return err.message
+}
+
+func (err codedError) GetMessage() string {
+	return err.message
+}
+
+func (err codedError) GetReasons() []Reason {
+	return err.reasons
+}
+
+func (err codedError) GetCode() string {
+	return err.code
+}
+
+func TestDescend_TraversesExternalHierarchicalErrors(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		codedError{
+			message: "external",
+			reasons: []Reason{errors.New("nested")},
+		},
+		"top",
+	)
+
+	messages := []string{}
+	err.Descend(func(reason Reason) {
+		messages = append(messages, fmt.Sprint(reason))
+	})
+
+	test.Equal([]string{"external", "nested"}, messages)
+}
+
+func TestContains_MatchesErrorsByCode(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		codedError{message: "no such user", code: "ENOUSER"},
+		"unable to login",
+	)
+
+	test.True(Contains(err, codedError{message: "other", code: "ENOUSER"}))
+	test.False(Contains(err, codedError{message: "other", code: "EACCESS"}))
+	test.False(
+		Contains(
+			Format(codedError{message: "a"}, "top"),
+			codedError{message: "b"},
+		),
+	)
+}
+
+func TestContains_TraversesExternalHierarchicalErrors(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		codedError{
+			message: "external",
+			reasons: []Reason{errors.New("deep")},
+		},
+		"top",
+	)
+
+	test.True(Contains(err, errors.New("deep")))
+	test.True(Contains(err.GetReasons()[0], errors.New("deep")))
+	test.False(Contains(err, errors.New("missing")))
+}
+
+func TestFind_TraversesExternalHierarchicalErrors(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		codedError{
+			message: "external",
+			reasons: []Reason{customSimpleError{text: "deep"}},
+		},
+		"top",
+	)
+
+	var found customSimpleError
+	test.True(Find(err, &found))
+	test.Equal("deep", found.text)
+}
+
+func TestSeverity_String(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal("unknown", SeverityUnknown.String())
+	test.Equal("warning", SeverityWarning.String())
+	test.Equal("critical", SeverityCritical.String())
+	test.Equal("unknown", Severity(100).String())
+}
+
+func TestContainsAll_RequiresEveryBranch(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		[]Reason{errors.New("first"), Format(errors.New("second"), "nested")},
+		"top",
+	)
+
+	test.True(ContainsAll(err, errors.New("first"), errors.New("second")))
+	test.False(ContainsAll(err, errors.New("first"), errors.New("third")))
+	test.True(ContainsAll(err))
+}
+
+func TestContainsAny_RequiresAnyBranch(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(errors.New("second"), "top")
+
+	test.True(ContainsAny(err, errors.New("first"), errors.New("second")))
+	test.False(ContainsAny(err, errors.New("first"), errors.New("third")))
+	test.False(ContainsAny(err))
+}
+
+func TestFormat_LiftsMessageOfKarmaOnEmptyMessage(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		Describe("port", 80).Format(
+			errors.New("connection refused"),
+			"unable to connect",
+		),
+		"",
+	)
+
+	test.Equal("unable to connect", err.GetMessage())
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ connection refused",
+			"├─ port: 80",
+			"└─ host: example.com",
+		),
+	)
+
+	test.EqualError(
+		Format(errors.New("plain"), ""),
+		output(
+			"",
+			"└─ plain",
+		),
+	)
+}
+
+func TestFormat_KeepsFieldsOfLiftedKarma(t *testing.T) {
+	test := assert.New(t)
+
+	inner := WithMetadata(
+		WithCategory(
+			FormatHere(errors.New("connection refused"), "unable to connect"),
+			CategoryTransient,
+		),
+		"attempt",
+		1,
+	)
+
+	err := Describe("host", "example.com").Format(inner, "")
+
+	test.Equal("unable to connect", err.GetMessage())
+	test.Equal(inner.Reason, err.Reason)
+	test.Equal(inner.SourceLocation, err.SourceLocation)
+
+	category, ok := GetCategory(err)
+	test.True(ok)
+	test.Equal(CategoryTransient, category)
+
+	attempt, ok := GetMetadata(err, "attempt")
+	test.True(ok)
+	test.Equal(1, attempt)
+
+	host, ok := GetContextValueTop(err, "host")
+	test.True(ok)
+	test.Equal("example.com", host)
+}
+
+func TestFormatIf_FormatsOnlyWhenConditionIsTrue(t *testing.T) {
+	test := assert.New(t)
+
+	reason := errors.New("timeout")
+
+	test.EqualError(
+		FormatIf(true, reason, "unable to connect to %s", "example.com"),
+		output(
+			"unable to connect to example.com",
+			"└─ timeout",
+		),
+	)
+
+	test.Equal(reason, FormatIf(false, reason, "unable to connect"))
+	test.EqualError(FormatIf(false, "plain", "unable to connect"), "plain")
+	test.NoError(FormatIf(false, nil, "unable to connect"))
+}
+
+func TestIsEmpty_DetectsMeaninglessErrors(t *testing.T) {
+	test := assert.New(t)
+
+	var pointer *Karma
+
+	test.True(IsEmpty(nil))
+	test.True(IsEmpty(Karma{}))
+	test.True(IsEmpty(&Karma{}))
+	test.True(IsEmpty(pointer))
+	test.True(IsEmpty(Format(nil, "")))
+	test.True(IsEmpty(Describe("host", "example.com").Format(nil, "")))
+
+	test.False(IsEmpty(Format(nil, "message")))
+	test.False(IsEmpty(Format(errors.New("reason"), "")))
+	test.False(IsEmpty(errors.New("")))
+}
+
+func TestIsEmpty_IsRespectedByErrorReturningFunctions(t *testing.T) {
+	test := assert.New(t)
+
+	test.NoError(Cause(Karma{}))
+	test.NoError(Flatten(Karma{}))
+	test.NoError(FlattenUnwrap(Karma{}))
+}
+
+func TestDescribeError_UsesTopLevelMessage(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(errors.New("timeout"), "unable to connect")
+
+	test.Equal(
+		[]interface{}{"cause", "unable to connect"},
+		DescribeError("cause", err).GetKeyValuePairs(),
+	)
+
+	test.Equal(
+		[]interface{}{"cause", "timeout"},
+		DescribeError("cause", errors.New("timeout")).GetKeyValuePairs(),
+	)
+
+	test.Equal(
+		[]interface{}{"cause", err.Error()},
+		DescribeErrorFull("cause", err).GetKeyValuePairs(),
+	)
+
+	test.Equal(
+		[]interface{}{"cause", nil},
+		DescribeError("cause", nil).GetKeyValuePairs(),
+	)
+}
+
+func TestNewContext_ReturnsEmptyContext(t *testing.T) {
+	test := assert.New(t)
+
+	context := NewContext()
+	test.NotNil(context)
+	test.Empty(context.GetKeyValuePairs())
+
+	context = context.Describe("host", "example.com")
+
+	test.Equal(
+		[]interface{}{"host", "example.com"},
+		context.GetKeyValuePairs(),
+	)
+	test.Nil(context.Next)
+
+	test.EqualError(
+		NewContext().Format(errors.New("timeout"), "unable to connect"),
+		output(
+			"unable to connect",
+			"└─ timeout",
+		),
+	)
+}
+
+func TestContext_UnmarshalJSON_AcceptsEmptyList(t *testing.T) {
+	test := assert.New(t)
+
+	var context Context
+
+	test.NoError(json.Unmarshal([]byte(`[]`), &context))
+	test.Empty(context.GetKeyValuePairs())
+}
+
+func TestContext_AppendTo_AddsContextToTopLevel(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		errors.New("timeout"),
+		"unable to connect",
+	)
+
+	appended := Describe("port", 80).Describe("user", "root").AppendTo(err)
+
+	test.EqualError(
+		appended,
+		output(
+			"unable to connect",
+			"├─ timeout",
+			"├─ host: example.com",
+			"├─ port: 80",
+			"└─ user: root",
+		),
+	)
+
+	test.Equal(
+		[]interface{}{"host", "example.com"},
+		err.Context.GetKeyValuePairs(),
+	)
+
+	var context *Context
+	test.Equal(err.Error(), context.AppendTo(err).Error())
+}
+
+func TestKarma_GetMergedContext_MergesAllLevels(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		[]Reason{
+			Describe("attempt", 1).Format(errors.New("timeout"), "failed"),
+			Describe("attempt", 2).Format(
+				Describe("errno", 111).Reason(errors.New("refused")),
+				"failed",
+			),
+		},
+		"unable to connect",
+	)
+
+	test.Equal(
+		[]interface{}{
+			"host", "example.com",
+			"attempt", 1,
+			"attempt", 2,
+			"errno", 111,
+		},
+		err.GetMergedContext().GetKeyValuePairs(),
+	)
+
+	test.Equal(
+		[]interface{}{"host", "example.com"},
+		err.GetContextAt(0).GetKeyValuePairs(),
+	)
+	test.Equal(
+		[]interface{}{"attempt", 1, "attempt", 2},
+		err.GetContextAt(1).GetKeyValuePairs(),
+	)
+	test.Equal(
+		[]interface{}{"errno", 111},
+		err.GetContextAt(2).GetKeyValuePairs(),
+	)
+	test.Nil(err.GetContextAt(10))
+}
+
+func TestGetMessages_ReturnsMessagesOfAllLevels(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		[]Reason{
+			Describe("host", "example.com").Reason(errors.New("timeout")),
+			Format(
+				joinErrors(errors.New("refused"), errors.New("")),
+				"unable to dial",
+			),
+		},
+		"unable to connect",
+	)
+
+	test.Equal(
+		[]string{
+			"unable to connect",
+			"timeout",
+			"unable to dial",
+			"refused",
+		},
+		err.GetMessages(),
+	)
+
+	test.Equal([]string{"plain"}, GetMessages(errors.New("plain")))
+	test.Nil(GetMessages(nil))
+}
+
+func TestPush_HandlesNilReasons(t *testing.T) {
+	test := assert.New(t)
+
+	test.True(IsEmpty(Push(nil)))
+	test.True(IsEmpty(Push(nil, nil, nil)))
+
+	karma := Push(nil, errors.New("a"), nil, errors.New("b"))
+	test.Equal("", karma.Message)
+	test.Equal(
+		[]Reason{errors.New("a"), errors.New("b")},
+		karma.GetReasons(),
+	)
+
+	test.EqualError(
+		Push("top", nil, errors.New("a"), nil),
+		output(
+			"top",
+			"└─ a",
+		),
+	)
+
+	test.EqualError(Push("top", nil), "top")
+}
+
+func TestPushNonNil_FiltersAllNilReasons(t *testing.T) {
+	test := assert.New(t)
+
+	test.True(IsEmpty(PushNonNil()))
+	test.True(IsEmpty(PushNonNil(nil, nil)))
+
+	test.EqualError(
+		PushNonNil(nil, "top", nil, errors.New("a"), nil),
+		output(
+			"top",
+			"└─ a",
+		),
+	)
+
+	test.EqualError(PushNonNil(nil, "top"), "top")
+
+	test.EqualError(
+		PushNonNil(Format(errors.New("a"), "top"), nil, errors.New("b")),
+		output(
+			"top",
+			"├─ a",
+			"└─ b",
+		),
+	)
+}
+
+func TestNewReason_ConvertsValuesToReasons(t *testing.T) {
+	test := assert.New(t)
+
+	err := errors.New("access denied")
+
+	test.Nil(NewReason(nil))
+	test.Equal(err, NewReason(err))
+	test.Equal("access denied", NewReason("access denied"))
+	test.Equal("42", NewReason(42))
+	test.Equal("[1 2]", NewReason([]int{1, 2}))
+
+	reason := NewReason([]byte("access denied"))
+	test.Implements((*fmt.Stringer)(nil), reason)
+	test.Equal("access denied", fmt.Sprint(reason))
+
+	test.EqualError(
+		Format(NewReason([]byte("access denied")), "unable to connect"),
+		output(
+			"unable to connect",
+			"└─ access denied",
+		),
+	)
+}
+
+func TestContextValueFormatter_FormatsDurationAndTime(t *testing.T) {
+	test := assert.New(t)
+
+	moment := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	test.EqualError(
+		Describe("elapsed", 500*time.Millisecond).
+			Describe("at", moment).
+			Reason("timeout"),
+		output(
+			"timeout",
+			"├─ elapsed: 500ms",
+			"└─ at: 2020-01-02T03:04:05Z",
+		),
+	)
+}
+
+func TestContextValueFormatter_FallsBackToSprintWhenNil(t *testing.T) {
+	test := assert.New(t)
+
+	formatter := ContextValueFormatter
+	ContextValueFormatter = nil
+	defer func() {
+		ContextValueFormatter = formatter
+	}()
+
+	test.EqualError(
+		Describe("elapsed", 500*time.Millisecond).
+			Describe("items", []int{1, 2}).
+			Reason("timeout"),
+		output(
+			"timeout",
+			"├─ elapsed: 500ms",
+			"└─ items: [1 2]",
+		),
+	)
+}
+
+func TestGetContextValueTop_SearchesTopLevelOnly(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		Describe("port", 80).Format(errors.New("timeout"), "unable to dial"),
+		"unable to connect",
+	)
+
+	host, ok := GetContextValueTop(err, "host")
+	test.True(ok)
+	test.Equal("example.com", host)
+
+	_, ok = GetContextValueTop(err, "port")
+	test.False(ok)
+
+	_, ok = GetContextValueTop(errors.New("timeout"), "host")
+	test.False(ok)
+
+	_, ok = GetContextValueTop(nil, "host")
+	test.False(ok)
+}
+
+func TestGetContextValueDeep_ReturnsFirstMatch(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		Push(
+			"unable to dial",
+			Describe("port", 80).Reason("timeout"),
+			Describe("port", 443).Reason("refused"),
+		),
+		"unable to connect",
+	)
+
+	host, ok := GetContextValueDeep(err, "host")
+	test.True(ok)
+	test.Equal("example.com", host)
+
+	port, ok := GetContextValueDeep(err, "port")
+	test.True(ok)
+	test.Equal(80, port)
+
+	_, ok = GetContextValueDeep(err, "user")
+	test.False(ok)
+
+	_, ok = GetContextValueDeep(nil, "host")
+	test.False(ok)
+}
+
+func TestGetContextValueAs_AssertsType(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("port", 80).Reason("timeout")
+
+	port, ok := GetContextValueAs[int](err, "port")
+	test.True(ok)
+	test.Equal(80, port)
+
+	host, ok := GetContextValueAs[string](err, "port")
+	test.False(ok)
+	test.Zero(host)
+
+	_, ok = GetContextValueAs[int](err, "host")
+	test.False(ok)
+}
+
+func TestFormat_UnwrapsFmtWrappedError(t *testing.T) {
+	test := assert.New(t)
+
+	inner := errors.New("timeout")
+
+	err := Format(fmt.Errorf("unable to dial: %w", inner), "unable to connect")
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ timeout",
+			"└─ fmt_wrap: unable to dial: timeout",
+		),
+	)
+	test.Equal(inner, err.Reason)
+	test.True(errors.Is(err, inner))
+
+	nested := Format(inner, "unable to dial")
+
+	test.EqualError(
+		Format(fmt.Errorf("retrying: %w", nested), "unable to connect"),
+		output(
+			"unable to connect",
+			"├─ unable to dial",
+			"│  └─ timeout",
+			"│",
+			"└─ fmt_wrap: retrying: unable to dial",
+			"   └─ timeout",
+		),
+	)
+
+	test.EqualError(
+		Format(fmt.Errorf("unable to dial: %v", inner), "unable to connect"),
+		output(
+			"unable to connect",
+			"└─ unable to dial: timeout",
+		),
+	)
+}
+
+func TestDescribeMany_DescribesAllPairs(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(
+		Describe("host", "example.com").Describe("port", 80),
+		DescribeMany("host", "example.com", "port", 80),
+	)
+
+	test.EqualError(
+		DescribeMany("host", "example.com", "port", 80).Format(
+			errors.New("timeout"),
+			"unable to connect",
+		),
+		output(
+			"unable to connect",
+			"├─ timeout",
+			"├─ host: example.com",
+			"└─ port: 80",
+		),
+	)
+
+	test.Nil(DescribeMany())
+}
+
+func TestDescribeMany_PanicsOnInvalidArguments(t *testing.T) {
+	test := assert.New(t)
+
+	test.PanicsWithValue(
+		"karma: odd number of key-value arguments: 3",
+		func() {
+			DescribeMany("host", "example.com", "port")
+		},
+	)
+
+	test.PanicsWithValue(
+		"karma: key at position 2 is int, not string",
+		func() {
+			DescribeMany("host", "example.com", 80, "port")
+		},
+	)
+}
+
+func TestContext_JSON_ReturnsObject(t *testing.T) {
+	test := assert.New(t)
+
+	context := Describe("port", 80).
+		Describe("host", "example.com").
+		Describe("port", 443)
+
+	data, err := context.JSON()
+	test.NoError(err)
+	test.Equal(`{"port":443,"host":"example.com"}`, string(data))
+
+	test.Equal(`{"port":443,"host":"example.com"}`, context.MustJSON())
+
+	var empty *Context
+	test.Equal(`{}`, empty.MustJSON())
+
+	test.Panics(func() {
+		Describe("channel", make(chan int)).MustJSON()
+	})
+}
+
+func TestContextJSONFormat_ControlsMarshalJSON(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("port", 80).
+		Describe("host", "example.com").
+		Describe("port", 443).
+		Format(errors.New("timeout"), "unable to connect")
+
+	ContextJSONFormat = ContextJSONFormatObject
+	defer func() {
+		ContextJSONFormat = ContextJSONFormatArray
+	}()
+
+	data := JSON(err)
+
+	test.Equal(
+		`{"reason":"timeout","message":"unable to connect",`+
+			`"context":{"port":443,"host":"example.com"}}`,
+		data,
+	)
+
+	restored, unmarshalErr := NewFromJSONString(data)
+	test.NoError(unmarshalErr)
+	test.Equal(
+		[]KeyValue{{"port", float64(443)}, {"host", "example.com"}},
+		restored.GetContext().GetKeyValues(),
+	)
+
+	ContextJSONFormat = ContextJSONFormatArray
+
+	test.Equal(
+		`{"reason":"timeout","message":"unable to connect","context":[`+
+			`{"key":"port","value":80},`+
+			`{"key":"host","value":"example.com"},`+
+			`{"key":"port","value":443}]}`,
+		JSON(err),
+	)
+}
+
+func TestContainsAt_ReturnsPathToFoundReason(t *testing.T) {
+	test := assert.New(t)
+
+	timeout := errors.New("timeout")
+
+	err := Push(
+		"unable to sync",
+		Push("unable to connect", errors.New("refused"), nil, timeout),
+		errors.New("denied"),
+	)
+
+	found, path, ok := ContainsAt(err, timeout)
+	test.True(ok)
+	test.Equal(timeout, found)
+	test.Equal([]int{0, 1}, path)
+
+	found, path, ok = ContainsAt(err, "denied")
+	test.True(ok)
+	test.EqualError(found.(error), "denied")
+	test.Equal([]int{1}, path)
+
+	found, path, ok = ContainsAt(timeout, timeout)
+	test.True(ok)
+	test.Equal(timeout, found)
+	test.Equal([]int{}, path)
+
+	found, path, ok = ContainsAt(err, errors.New("not found"))
+	test.False(ok)
+	test.Nil(found)
+	test.Nil(path)
+}
+
 func ExampleContext_MultipleKeyValues() {
 	foo := func(arg string) error {
 		return fmt.Errorf("unable to foo on %s", arg)
@@ -0,0 +1,101 @@
+package karma
+
+import (
+	"sync"
+)
+
+type lazyContext struct {
+	once    sync.Once
+	factory func() *Context
+	context *Context
+}
+
+func (lazy *lazyContext) get() *Context {
+	lazy.once.Do(func() {
+		lazy.context = lazy.factory()
+		lazy.factory = nil
+	})
+
+	return lazy.context
+}
+
+// FormatLazy is the same as Format(), but context is created by given
+// function only when it's actually needed, e.g. when String() or
+// GetContext() is called. Function is called at most once.
+//
+// Lazy pairs are not taken into account when default context is merged and
+// are not passed to recorder and writer sink, since they are used at the
+// moment of message creation.
+func FormatLazy(
+	reason Reason,
+	context func() *Context,
+	message string,
+	args ...interface{},
+) Karma {
+	var lazy *Context
+	if context != nil {
+		lazy = &Context{lazy: &lazyContext{factory: context}}
+	}
+
+	return format(lazy, reason, message, args)
+}
+
+// walkEager is the same as Walk(), but lazy pairs are skipped without
+// evaluation.
+func (context *Context) walkEager(callback func(string, interface{})) {
+	for node := context; node != nil; node = node.Next {
+		if node.lazy != nil || (node.Key == "" && node.Value == nil) {
+			continue
+		}
+
+		callback(node.Key, node.Value)
+	}
+}
+
+// lookupEager is the same as lookup(), but lazy pairs are skipped without
+// evaluation.
+func (context *Context) lookupEager(key string) (interface{}, bool) {
+	var (
+		result interface{}
+		found  bool
+	)
+
+	context.walkEager(func(name string, value interface{}) {
+		if name == key {
+			result = value
+			found = true
+		}
+	})
+
+	return result, found
+}
+
+// withoutLazy returns context list without lazy pairs. Context list is
+// returned as is if it has no lazy pairs.
+func (context *Context) withoutLazy() *Context {
+	for node := context; node != nil; node = node.Next {
+		if node.lazy != nil {
+			var result *Context
+
+			context.walkEager(func(key string, value interface{}) {
+				result = result.Describe(key, value)
+			})
+
+			return result
+		}
+	}
+
+	return context
+}
+
+// resolve returns context list without lazy pairs. Context list is returned
+// as is if it has no lazy pairs.
+func (context *Context) resolve() *Context {
+	for node := context; node != nil; node = node.Next {
+		if node.lazy != nil {
+			return appendContext(nil, context)
+		}
+	}
+
+	return context
+}
@@ -0,0 +1,153 @@
+package karma
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatLazy_EvaluatesContextOnce(t *testing.T) {
+	test := assert.New(t)
+
+	calls := 0
+
+	err := FormatLazy(
+		errors.New("timeout"),
+		func() *Context {
+			calls++
+
+			return Describe("host", "example.com").Describe("port", 80)
+		},
+		"unable to connect to %s",
+		"server",
+	)
+
+	test.Equal(0, calls)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect to server",
+			"├─ timeout",
+			"├─ host: example.com",
+			"└─ port: 80",
+		),
+	)
+	test.Equal(1, calls)
+
+	test.Equal(
+		[]KeyValue{{"host", "example.com"}, {"port", 80}},
+		err.GetContext().GetKeyValues(),
+	)
+	test.Equal(1, calls)
+}
+
+func TestFormatLazy_EvaluatesContextOnGetContext(t *testing.T) {
+	test := assert.New(t)
+
+	calls := 0
+
+	err := FormatLazy(nil, func() *Context {
+		calls++
+
+		return Describe("host", "example.com")
+	}, "unable to connect")
+
+	err = err.Annotate("port", 80)
+	test.Equal(0, calls)
+
+	test.Equal(
+		[]KeyValue{{"host", "example.com"}, {"port", 80}},
+		err.GetContext().GetKeyValues(),
+	)
+	test.Equal(1, calls)
+}
+
+func TestFormatLazy_IsSafeForConcurrentUse(t *testing.T) {
+	test := assert.New(t)
+
+	var (
+		mutex sync.Mutex
+		calls = 0
+	)
+
+	err := FormatLazy(nil, func() *Context {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		calls++
+
+		return Describe("host", "example.com")
+	}, "unable to connect")
+
+	var group sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+
+			test.Equal("unable to connect\n└─ host: example.com", err.Error())
+		}()
+	}
+
+	group.Wait()
+
+	test.Equal(1, calls)
+}
+
+func TestFormatLazy_AcceptsNilFactory(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(FormatLazy(nil, nil, "unable to connect"), "unable to connect")
+}
+
+func TestFormatLazy_DoesNotEvaluateContextOnCreation(t *testing.T) {
+	test := assert.New(t)
+
+	SetDefaultContext(Describe("service", "api"))
+	defer ClearDefaultContext()
+
+	var records bytes.Buffer
+
+	StartRecording(&records)
+	defer StopRecording()
+
+	var buffer bytes.Buffer
+
+	SetWriterSink(NewWriterSink(&buffer, WriterSinkText))
+	defer SetWriterSink(nil)
+
+	calls := 0
+
+	err := FormatLazy(errors.New("timeout"), func() *Context {
+		calls++
+
+		return Describe("host", "example.com")
+	}, "unable to connect")
+
+	test.Equal(0, calls)
+	test.NotContains(records.String(), "host")
+	test.Equal(
+		output(
+			"unable to connect",
+			"├─ timeout",
+			"└─ service: api",
+			"",
+		),
+		buffer.String(),
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ timeout",
+			"├─ host: example.com",
+			"└─ service: api",
+		),
+	)
+	test.Equal(1, calls)
+}
@@ -0,0 +1,61 @@
+package karma
+
+// MapMessages returns copy of given error with fn applied to message of every
+// hierarchical level, depth of top-level is 0. Reasons which are plain errors
+// are treated as levels without reasons and replaced with messages returned
+// by fn. If fn returns empty string, level is collapsed: its reasons and
+// context are merged into the parent level.
+func MapMessages(err error, fn func(msg string, depth int) string) Karma {
+	if err == nil {
+		return Karma{}
+	}
+
+	karma, ok := getKarma(err)
+	if !ok {
+		return Karma{Message: fn(err.Error(), 0)}
+	}
+
+	return mapMessages(*karma, 0, fn)
+}
+
+func mapMessages(
+	karma Karma,
+	depth int,
+	fn func(msg string, depth int) string,
+) Karma {
+	var reasons []Reason
+
+	for _, reason := range karma.GetReasons() {
+		nested, ok := getKarma(reason)
+		if !ok {
+			if err, ok := reason.(error); ok {
+				message := fn(err.Error(), depth+1)
+				if message != "" {
+					reasons = append(reasons, Karma{Message: message})
+				}
+
+				continue
+			}
+
+			reasons = append(reasons, reason)
+			continue
+		}
+
+		mapped := mapMessages(*nested, depth+1, fn)
+		if mapped.Message == "" && nested.Message != "" {
+			reasons = append(reasons, mapped.GetReasons()...)
+			karma.Context = appendContext(karma.Context, mapped.Context)
+			continue
+		}
+
+		reasons = append(reasons, mapped)
+	}
+
+	karma.Reason = joinReasons(reasons)
+
+	if karma.Message != "" {
+		karma.Message = fn(karma.Message, depth)
+	}
+
+	return karma
+}
@@ -0,0 +1,99 @@
+package karma
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapMessages_TransformsEveryMessage(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		Describe("host", "example.com").Format(
+			errors.New("connection refused"),
+			"unable to connect",
+		),
+		"unable to load profile",
+	)
+
+	test.EqualError(
+		MapMessages(err, func(msg string, depth int) string {
+			return strings.Repeat(">", depth) + strings.ToUpper(msg)
+		}),
+		output(
+			"UNABLE TO LOAD PROFILE",
+			"└─ >UNABLE TO CONNECT",
+			"   ├─ >>CONNECTION REFUSED",
+			"   └─ host: example.com",
+		),
+	)
+
+	test.Equal("unable to load profile", err.GetMessage())
+}
+
+func TestMapMessages_CollapsesLevelOnEmptyMessage(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		Describe("host", "example.com").Format(
+			[]Reason{errors.New("first"), errors.New("second")},
+			"internal details",
+		),
+		"unable to load profile",
+	)
+
+	test.EqualError(
+		MapMessages(err, func(msg string, depth int) string {
+			if depth == 1 {
+				return ""
+			}
+
+			return msg
+		}),
+		output(
+			"unable to load profile",
+			"├─ first",
+			"├─ second",
+			"└─ host: example.com",
+		),
+	)
+}
+
+func TestMapMessages_TransformsMessagesOfPlainErrors(t *testing.T) {
+	test := assert.New(t)
+
+	err := Push(
+		Format(errors.New("password is incorrect"), "unable to login"),
+		errors.New("user is locked"),
+	)
+
+	test.EqualError(
+		MapMessages(err, func(msg string, depth int) string {
+			if msg == "user is locked" {
+				return ""
+			}
+
+			return strings.Repeat(">", depth) + msg
+		}),
+		output(
+			"unable to login",
+			"└─ >password is incorrect",
+		),
+	)
+}
+
+func TestMapMessages_HandlesNonHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		MapMessages(errors.New("denied"), func(msg string, _ int) string {
+			return "public: " + msg
+		}),
+		"public: denied",
+	)
+
+	test.Equal(Karma{}, MapMessages(nil, nil))
+}
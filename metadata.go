@@ -0,0 +1,83 @@
+package karma
+
+import (
+	"sort"
+)
+
+// metadata holds values attached by WithMetadata(). Values are never changed
+// after creation, WithMetadata() creates new metadata instead.
+type metadata struct {
+	values map[string]interface{}
+}
+
+func (metadata *metadata) get() map[string]interface{} {
+	if metadata == nil {
+		return nil
+	}
+
+	return metadata.values
+}
+
+// WithMetadata returns copy of given message with typed metadata value
+// attached under specified key. Unlike context, metadata is not rendered by
+// String() and is not marshaled to JSON unless
+// DefaultRenderConfig.IncludeMetadata is set, so it can hold arbitrary
+// objects like *http.Request.
+func WithMetadata(err Karma, key string, value interface{}) Karma {
+	previous := err.metadata.get()
+
+	values := make(map[string]interface{}, len(previous)+1)
+	for key, value := range previous {
+		values[key] = value
+	}
+
+	values[key] = value
+
+	err.metadata = &metadata{values: values}
+
+	return err
+}
+
+// GetMetadata returns metadata value with specified key, which is searched in
+// the whole hierarchy, starting from top-level.
+func GetMetadata(err error, key string) (interface{}, bool) {
+	var (
+		result interface{}
+		found  bool
+	)
+
+	walk(err, 0, func(_ int, reason Reason, _ *Context) bool {
+		if karma, ok := getKarma(reason); ok {
+			result, found = karma.metadata.get()[key]
+		}
+
+		return !found
+	})
+
+	return result, found
+}
+
+// GetMetadataAs returns metadata value with specified key if it has type T.
+func GetMetadataAs[T any](err error, key string) (T, bool) {
+	value, ok := GetMetadata(err, key)
+	if !ok {
+		var zero T
+
+		return zero, false
+	}
+
+	typed, ok := value.(T)
+
+	return typed, ok
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,119 @@
+package karma
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMetadata_IsNotRenderedByDefault(t *testing.T) {
+	test := assert.New(t)
+
+	request, _ := http.NewRequest("GET", "http://example.com/", nil)
+
+	err := WithMetadata(
+		Describe("host", "example.com").Format(
+			errors.New("timeout"),
+			"unable to fetch",
+		),
+		"request",
+		request,
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to fetch",
+			"├─ timeout",
+			"└─ host: example.com",
+		),
+	)
+
+	test.JSONEq(
+		`{"reason":"timeout","message":"unable to fetch",`+
+			`"context":[{"key":"host","value":"example.com"}]}`,
+		JSON(err),
+	)
+
+	value, ok := GetMetadataAs[*http.Request](Format(err, "top"), "request")
+	test.True(ok)
+	test.Same(request, value)
+}
+
+func TestWithMetadata_IsRenderedWhenIncluded(t *testing.T) {
+	test := assert.New(t)
+
+	DefaultRenderConfig.IncludeMetadata = true
+	defer func() {
+		DefaultRenderConfig.IncludeMetadata = false
+	}()
+
+	err := WithMetadata(
+		WithMetadata(Format(nil, "unable to fetch"), "b", 2),
+		"a",
+		1,
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to fetch",
+			"├─ a: 1",
+			"└─ b: 2",
+		),
+	)
+
+	test.JSONEq(
+		`{"reason":null,"message":"unable to fetch",`+
+			`"metadata":{"a":1,"b":2}}`,
+		JSON(err),
+	)
+
+	restored, unmarshalErr := NewFromJSONString(JSON(err))
+	test.NoError(unmarshalErr)
+	value, ok := GetMetadata(restored, "b")
+	test.True(ok)
+	test.Equal(float64(2), value)
+}
+
+func TestWithMetadata_DoesNotChangeSelf(t *testing.T) {
+	test := assert.New(t)
+
+	err := WithMetadata(Format(nil, "message"), "a", 1)
+
+	WithMetadata(err, "b", 2)
+
+	test.Len(err.metadata.get(), 1)
+}
+
+func TestGetMetadata_ReturnsFalseForMissingKey(t *testing.T) {
+	test := assert.New(t)
+
+	_, ok := GetMetadata(Format(nil, "message"), "missing")
+	test.False(ok)
+
+	_, ok = GetMetadataAs[string](
+		WithMetadata(Format(nil, "message"), "number", 1),
+		"number",
+	)
+	test.False(ok)
+}
+
+func TestWithMetadata_KeepsKarmaComparable(t *testing.T) {
+	test := assert.New(t)
+
+	var err error = WithMetadata(Format(nil, "message"), "a", 1)
+	var other error = WithMetadata(Format(nil, "message"), "a", 1)
+
+	test.NotPanics(func() {
+		test.True(err == err)
+		test.False(err == other)
+
+		_ = map[error]int{err: 1}
+	})
+
+	test.True(errors.Is(err, err))
+	test.False(errors.Is(err, other))
+}
@@ -0,0 +1,145 @@
+package karma
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+const (
+	// NetTimeoutKey is a context key which is used to store result of
+	// Timeout() method of net.Error.
+	NetTimeoutKey = "_net_timeout"
+
+	// NetTemporaryKey is a context key which is used to store result of
+	// Temporary() method of net.Error.
+	NetTemporaryKey = "_net_temporary"
+)
+
+// WrapNetError creates new hierarchical message with given network error as
+// reason. Results of Timeout() and Temporary() methods are added to the
+// context, so they are not lost when error is marshaled or rendered.
+func WrapNetError(err net.Error, message string) Karma {
+	if err == nil {
+		return Format(nil, "%s", message)
+	}
+
+	return Describe(NetTimeoutKey, err.Timeout()).
+		Describe(NetTemporaryKey, err.Temporary()).
+		Format(err, "%s", message)
+}
+
+// GetNetError returns the first network error found in the hierarchy of given
+// error.
+func GetNetError(err error) (net.Error, bool) {
+	var result net.Error
+
+	walk(err, 0, func(_ int, reason Reason, _ *Context) bool {
+		netErr, ok := reason.(net.Error)
+		if ok {
+			result = netErr
+		}
+
+		return !ok
+	})
+
+	return result, result != nil
+}
+
+// FormatAddr returns string representation of network address prefixed with
+// network name, e.g. tcp://10.0.0.1:443, udp://[::1]:53 or
+// unix:///run/app.sock.
+func FormatAddr(addr net.Addr) string {
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		if addr == nil {
+			return "tcp://"
+		}
+
+		return "tcp://" + addr.String()
+	case *net.UDPAddr:
+		if addr == nil {
+			return "udp://"
+		}
+
+		return "udp://" + addr.String()
+	case *net.IPAddr:
+		if addr == nil {
+			return "ip://"
+		}
+
+		return "ip://" + addr.String()
+	case *net.UnixAddr:
+		if addr == nil {
+			return "unix://"
+		}
+
+		return addr.Net + "://" + addr.Name
+	case nil:
+		return "<nil>"
+	default:
+		return addr.Network() + "://" + addr.String()
+	}
+}
+
+// NetContextValueFormatter formats net.Addr values using FormatAddr() and
+// net.IP values as IP addresses, so it's clear which kind of socket address
+// is described, e.g. tcp://10.0.0.1:443. Other values are formatted by
+// ContextValueFormatter. It can be registered for specific keys:
+//
+//	karma.RegisterContextValueFormatter("addr", karma.NetContextValueFormatter)
+func NetContextValueFormatter(value interface{}) string {
+	return wrapNetFormatter(ContextValueFormatter)(value)
+}
+
+var netFormatterInstallation = struct {
+	sync.Mutex
+	installed bool
+}{}
+
+// InstallNetFormatter replaces ContextValueFormatter with formatter, which
+// handles network addresses as NetContextValueFormatter() does and passes
+// other values to previous formatter. Returned function restores previous
+// formatter. Repeated calls have no effect until formatter is restored.
+func InstallNetFormatter() (uninstall func()) {
+	netFormatterInstallation.Lock()
+	defer netFormatterInstallation.Unlock()
+
+	if netFormatterInstallation.installed {
+		return func() {}
+	}
+
+	previous := ContextValueFormatter
+
+	ContextValueFormatter = wrapNetFormatter(previous)
+	netFormatterInstallation.installed = true
+
+	return func() {
+		netFormatterInstallation.Lock()
+		defer netFormatterInstallation.Unlock()
+
+		if !netFormatterInstallation.installed {
+			return
+		}
+
+		ContextValueFormatter = previous
+		netFormatterInstallation.installed = false
+	}
+}
+
+func wrapNetFormatter(next func(interface{}) string) func(interface{}) string {
+	return func(value interface{}) string {
+		switch value := value.(type) {
+		case net.Addr:
+			return FormatAddr(value)
+		case net.IP:
+			return value.String()
+		}
+
+		if next == nil {
+			return fmt.Sprint(value)
+		}
+
+		return next(value)
+	}
+}
@@ -0,0 +1,169 @@
+package karma
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapNetError_AddsTimeoutAndTemporaryToContext(t *testing.T) {
+	test := assert.New(t)
+
+	netErr := &net.DNSError{
+		Err:         "i/o timeout",
+		Name:        "example.com",
+		IsTimeout:   true,
+		IsTemporary: true,
+	}
+
+	err := WrapNetError(netErr, "unable to resolve host")
+
+	test.EqualError(
+		err,
+		output(
+			"unable to resolve host",
+			"├─ lookup example.com: i/o timeout",
+			"├─ _net_timeout: true",
+			"└─ _net_temporary: true",
+		),
+	)
+
+	test.EqualError(WrapNetError(nil, "no error"), "no error")
+}
+
+func TestGetNetError_ReturnsOriginalError(t *testing.T) {
+	test := assert.New(t)
+
+	netErr := &net.DNSError{Err: "no such host", Name: "example.com"}
+
+	found, ok := GetNetError(
+		Format(WrapNetError(netErr, "unable to resolve"), "unable to connect"),
+	)
+	test.True(ok)
+	test.Same(netErr, found)
+
+	_, ok = GetNetError(Format(errors.New("plain"), "unable to connect"))
+	test.False(ok)
+
+	_, ok = GetNetError(nil)
+	test.False(ok)
+}
+
+func TestFormatAddr_PrefixesAddressWithNetwork(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(
+		"tcp://10.0.0.1:443",
+		FormatAddr(&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 443}),
+	)
+	test.Equal(
+		"udp://[::1]:53",
+		FormatAddr(&net.UDPAddr{IP: net.ParseIP("::1"), Port: 53}),
+	)
+	test.Equal(
+		"ip://10.0.0.1",
+		FormatAddr(&net.IPAddr{IP: net.ParseIP("10.0.0.1")}),
+	)
+	test.Equal(
+		"unix:///run/app.sock",
+		FormatAddr(&net.UnixAddr{Net: "unix", Name: "/run/app.sock"}),
+	)
+	test.Equal(
+		"unixgram:///run/app.sock",
+		FormatAddr(&net.UnixAddr{Net: "unixgram", Name: "/run/app.sock"}),
+	)
+	test.Equal("<nil>", FormatAddr(nil))
+}
+
+func TestFormatAddr_HandlesTypedNilAddresses(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal("tcp://", FormatAddr((*net.TCPAddr)(nil)))
+	test.Equal("udp://", FormatAddr((*net.UDPAddr)(nil)))
+	test.Equal("ip://", FormatAddr((*net.IPAddr)(nil)))
+	test.Equal("unix://", FormatAddr((*net.UnixAddr)(nil)))
+}
+
+func TestInstallNetFormatter_FormatsNetworkValues(t *testing.T) {
+	test := assert.New(t)
+
+	uninstall := InstallNetFormatter()
+	defer uninstall()
+
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 443}
+
+	err := Describe("addr", addr).
+		Describe("ip", net.ParseIP("10.0.0.2")).
+		Describe("port", 443).
+		Format(errors.New("connection reset by peer"), "unable to read")
+
+	test.EqualError(
+		err,
+		output(
+			"unable to read",
+			"├─ connection reset by peer",
+			"├─ addr: tcp://10.0.0.1:443",
+			"├─ ip: 10.0.0.2",
+			"└─ port: 443",
+		),
+	)
+}
+
+func TestNetContextValueFormatter_CanBeRegisteredForKey(t *testing.T) {
+	test := assert.New(t)
+
+	RegisterContextValueFormatter("addr", NetContextValueFormatter)
+	defer UnregisterContextValueFormatter("addr")
+
+	addr := &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 53}
+
+	err := Describe("addr", addr).
+		Describe("name", "example.com").
+		Reason("timeout")
+
+	test.EqualError(
+		err,
+		output(
+			"timeout",
+			"├─ addr: udp://10.0.0.1:53",
+			"└─ name: example.com",
+		),
+	)
+}
+
+func TestInstallNetFormatter_IsIdempotentAndCanBeUndone(t *testing.T) {
+	test := assert.New(t)
+
+	formatter := ContextValueFormatter
+	defer func() {
+		ContextValueFormatter = formatter
+	}()
+
+	var calls int
+
+	ContextValueFormatter = func(value interface{}) string {
+		calls++
+		return formatter(value)
+	}
+
+	uninstall := InstallNetFormatter()
+	InstallNetFormatter()()
+	InstallNetFormatter()
+
+	test.Equal("tcp://10.0.0.1:443", ContextValueFormatter(
+		&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 443},
+	))
+	test.Equal("443", ContextValueFormatter(443))
+	test.Equal(1, calls)
+
+	uninstall()
+
+	test.Equal(
+		"10.0.0.1:443",
+		ContextValueFormatter(
+			&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 443},
+		),
+	)
+}
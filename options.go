@@ -0,0 +1,114 @@
+package karma
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+const (
+	// CodeKey is a context key which is used to store error code set by
+	// WithCode().
+	CodeKey = "_code"
+
+	// SeverityKey is a context key which is used to store error severity set
+	// by WithSeverity().
+	SeverityKey = "_severity"
+
+	// StackKey is a context key which is used to store stack trace added by
+	// WithStack().
+	StackKey = "_stack"
+
+	// TimestampKey is a context key which is used to store time of error
+	// creation added by WithTimestamp().
+	TimestampKey = "_timestamp"
+
+	// CallerKey is a context key which is used to store location of code
+	// which created error, added by WithCaller().
+	CallerKey = "_caller"
+)
+
+// FormatOption configures hierarchical message created by FormatOptions().
+type FormatOption func(*Karma)
+
+// FormatOptions creates new hierarchical message with given options applied.
+// Unlike Format(), message is used as is and is not treated as format string.
+func FormatOptions(reason Reason, message string, opts ...FormatOption) Karma {
+	return newKarma(nil, reason, message, GetDefaultContext(nil), opts...)
+}
+
+// WithCode sets machine-readable error code, which can be obtained later
+// using GetCode().
+func WithCode(code string) FormatOption {
+	return func(karma *Karma) {
+		karma.Context = karma.Context.Describe(CodeKey, code)
+	}
+}
+
+// WithSeverity sets error severity, which can be obtained later using
+// GetSeverity().
+func WithSeverity(severity Severity) FormatOption {
+	return func(karma *Karma) {
+		karma.Context = karma.Context.Describe(SeverityKey, severity)
+	}
+}
+
+// WithStack adds stack trace of current goroutine to the context.
+func WithStack() FormatOption {
+	stack := string(debug.Stack())
+
+	return func(karma *Karma) {
+		karma.Context = karma.Context.Describe(StackKey, stack)
+	}
+}
+
+// WithTimestamp adds time of error creation to the context.
+func WithTimestamp() FormatOption {
+	return func(karma *Karma) {
+		karma.Context = karma.Context.Describe(TimestampKey, time.Now())
+	}
+}
+
+// WithCaller adds file and line of code which called FormatOptions() to the
+// context.
+func WithCaller() FormatOption {
+	caller := "unknown"
+
+	_, file, line, ok := runtime.Caller(1)
+	if ok {
+		caller = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	return func(karma *Karma) {
+		karma.Context = karma.Context.Describe(CallerKey, caller)
+	}
+}
+
+// GetCode returns error code set by WithCode(). It implements Coder
+// interface.
+func (karma Karma) GetCode() string {
+	value, _ := karma.GetContext().lookup(CodeKey)
+
+	code, _ := value.(string)
+
+	return code
+}
+
+// GetSeverity returns error severity set by WithSeverity().
+func (karma Karma) GetSeverity() Severity {
+	value, ok := karma.GetContext().lookup(SeverityKey)
+	if !ok {
+		return SeverityUnknown
+	}
+
+	if severity, ok := value.(Severity); ok {
+		return severity
+	}
+
+	if severity, ok := contextInt(value); ok {
+		return Severity(severity)
+	}
+
+	return SeverityUnknown
+}
@@ -0,0 +1,106 @@
+package karma
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatOptions_SetsCodeAndSeverity(t *testing.T) {
+	test := assert.New(t)
+
+	err := FormatOptions(
+		errors.New("no such file"),
+		"unable to read config",
+		WithCode("ENOCONFIG"),
+		WithSeverity(SeverityCritical),
+	)
+
+	test.Equal("ENOCONFIG", err.GetCode())
+	test.Equal(SeverityCritical, err.GetSeverity())
+	test.EqualError(
+		err,
+		output(
+			"unable to read config",
+			"├─ no such file",
+			"├─ _code: ENOCONFIG",
+			"└─ _severity: critical",
+		),
+	)
+}
+
+func TestFormatOptions_DoesNotFormatMessage(t *testing.T) {
+	test := assert.New(t)
+
+	err := FormatOptions(nil, "100% done")
+
+	test.Equal("100% done", err.GetMessage())
+	test.Equal("", err.GetCode())
+	test.Equal(SeverityUnknown, err.GetSeverity())
+}
+
+func TestFormatOptions_AddsStackTimestampAndCaller(t *testing.T) {
+	test := assert.New(t)
+
+	before := time.Now()
+
+	err := FormatOptions(
+		nil,
+		"unexpected",
+		WithStack(),
+		WithTimestamp(),
+		WithCaller(),
+	)
+
+	stack, ok := err.GetContext().lookup(StackKey)
+	test.True(ok)
+	test.Contains(stack, "goroutine")
+
+	timestamp, ok := err.GetContext().lookup(TimestampKey)
+	test.True(ok)
+	test.False(timestamp.(time.Time).Before(before))
+
+	caller, ok := err.GetContext().lookup(CallerKey)
+	test.True(ok)
+	test.True(strings.Contains(caller.(string), "options_test.go:"))
+}
+
+func TestGetSeverity_RestoresSeverityFromJSON(t *testing.T) {
+	test := assert.New(t)
+
+	data := JSON(FormatOptions(nil, "message", WithSeverity(SeverityWarning)))
+
+	var err Karma
+	test.NoError(err.UnmarshalJSON([]byte(data)))
+
+	test.Equal(SeverityWarning, err.GetSeverity())
+}
+
+func TestContains_MatchesKarmaByCode(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		FormatOptions(nil, "user not found", WithCode("ENOUSER")),
+		"unable to login",
+	)
+
+	test.True(
+		Contains(err, FormatOptions(nil, "no user", WithCode("ENOUSER"))),
+	)
+}
+
+func TestFormatOptions_KeepsFmtWrappedErrorAsIs(t *testing.T) {
+	test := assert.New(t)
+
+	reason := fmt.Errorf("a: %w", io.EOF)
+
+	err := FormatOptions(reason, "b")
+
+	test.Equal(reason, err.Reason)
+	test.Nil(err.Context)
+}
@@ -0,0 +1,28 @@
+package karma
+
+import (
+	"os"
+)
+
+// WrapOSError creates new hierarchical message from given error. If error is
+// *os.PathError, *os.LinkError or *os.SyscallError, its fields are added to
+// the context and wrapped error is used as reason. Otherwise error itself is
+// used as reason.
+func WrapOSError(err error, message string) Karma {
+	switch typed := err.(type) {
+	case *os.PathError:
+		return Describe("os_op", typed.Op).
+			Describe("os_path", typed.Path).
+			Format(typed.Err, "%s", message)
+	case *os.LinkError:
+		return Describe("os_op", typed.Op).
+			Describe("os_old_path", typed.Old).
+			Describe("os_new_path", typed.New).
+			Format(typed.Err, "%s", message)
+	case *os.SyscallError:
+		return Describe("syscall_name", typed.Syscall).
+			Format(typed.Err, "%s", message)
+	default:
+		return Format(err, "%s", message)
+	}
+}
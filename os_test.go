@@ -0,0 +1,75 @@
+package karma
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapOSError_ExtractsPathError(t *testing.T) {
+	test := assert.New(t)
+
+	_, err := os.Open("/nonexistent/file")
+
+	wrapped := WrapOSError(err, "unable to read config")
+
+	test.EqualError(
+		wrapped,
+		output(
+			"unable to read config",
+			"├─ no such file or directory",
+			"├─ os_op: open",
+			"└─ os_path: /nonexistent/file",
+		),
+	)
+	test.True(errors.Is(wrapped, os.ErrNotExist))
+}
+
+func TestWrapOSError_ExtractsLinkAndSyscallErrors(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		WrapOSError(
+			&os.LinkError{
+				Op:  "rename",
+				Old: "/a",
+				New: "/b",
+				Err: errors.New("cross-device link"),
+			},
+			"unable to move",
+		),
+		output(
+			"unable to move",
+			"├─ cross-device link",
+			"├─ os_op: rename",
+			"├─ os_old_path: /a",
+			"└─ os_new_path: /b",
+		),
+	)
+
+	test.EqualError(
+		WrapOSError(
+			os.NewSyscallError("fsync", errors.New("i/o error")),
+			"unable to sync",
+		),
+		output(
+			"unable to sync",
+			"├─ i/o error",
+			"└─ syscall_name: fsync",
+		),
+	)
+}
+
+func TestWrapOSError_FallsBackToFormat(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		WrapOSError(errors.New("plain"), "100% failed"),
+		output(
+			"100% failed",
+			"└─ plain",
+		),
+	)
+}
@@ -0,0 +1,58 @@
+package karma
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicStackKey is a context key which is used to store stack trace of
+// recovered panic.
+const PanicStackKey = "_panic_stack"
+
+// WrapPanic creates new hierarchical message from value returned by
+// recover(). Stack trace of current goroutine is added to the context.
+func WrapPanic(r interface{}, message string) Karma {
+	var reason Reason
+
+	switch typed := r.(type) {
+	case error:
+		reason = typed
+	case string:
+		reason = typed
+	default:
+		reason = fmt.Sprint(typed)
+	}
+
+	return Describe(PanicStackKey, string(debug.Stack())).
+		Format(reason, "%s", message)
+}
+
+// DeferRecover recovers from panic and stores it as hierarchical message
+// into errPtr. It should be called directly in defer statement:
+//
+//	defer karma.DeferRecover(&err, "unexpected panic")
+func DeferRecover(errPtr *error, message string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	*errPtr = WrapPanic(r, message)
+}
+
+// Panic creates new hierarchical message same as Format() and panics with it.
+func Panic(reason Reason, message string, args ...interface{}) {
+	panic(Format(reason, message, args...))
+}
+
+// PanicIf is the same as Panic(), but panics only if condition is true.
+func PanicIf(
+	condition bool,
+	reason Reason,
+	message string,
+	args ...interface{},
+) {
+	if condition {
+		Panic(reason, message, args...)
+	}
+}
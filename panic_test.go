@@ -0,0 +1,84 @@
+package karma
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapPanic_ConvertsRecoveredValue(t *testing.T) {
+	test := assert.New(t)
+
+	err := WrapPanic(io.EOF, "unexpected panic")
+	test.Equal(io.EOF, err.Reason)
+	test.Equal("unexpected panic", err.Message)
+	test.True(errors.Is(err, io.EOF))
+
+	test.Equal("oops", WrapPanic("oops", "unexpected panic").Reason)
+	test.Equal("42", WrapPanic(42, "unexpected panic").Reason)
+}
+
+func TestWrapPanic_AddsStackTrace(t *testing.T) {
+	test := assert.New(t)
+
+	values := WrapPanic("oops", "unexpected panic").GetContext().GetKeyValuePairs()
+	test.Len(values, 2)
+	test.Equal(PanicStackKey, values[0])
+	test.Contains(values[1], "TestWrapPanic_AddsStackTrace")
+}
+
+func TestDeferRecover_StoresPanicAsError(t *testing.T) {
+	test := assert.New(t)
+
+	run := func() (err error) {
+		defer DeferRecover(&err, "unable to run")
+
+		panic("oops")
+	}
+
+	err := run()
+	test.Error(err)
+
+	karma, ok := err.(Karma)
+	test.True(ok)
+	test.Equal("unable to run", karma.Message)
+	test.Equal("oops", karma.Reason)
+	test.Contains(karma.GetContext().GetKeyValuePairs()[1], "TestDeferRecover")
+}
+
+func TestDeferRecover_KeepsErrorWithoutPanic(t *testing.T) {
+	test := assert.New(t)
+
+	run := func() (err error) {
+		defer DeferRecover(&err, "unable to run")
+
+		return io.EOF
+	}
+
+	test.Equal(io.EOF, run())
+}
+
+func TestPanic_PanicsWithKarma(t *testing.T) {
+	test := assert.New(t)
+
+	test.PanicsWithError(
+		Format(errors.New("negative"), "invalid size %d", -1).Error(),
+		func() {
+			Panic(errors.New("negative"), "invalid size %d", -1)
+		},
+	)
+}
+
+func TestPanicIf_PanicsOnlyOnTrueCondition(t *testing.T) {
+	test := assert.New(t)
+
+	test.NotPanics(func() {
+		PanicIf(false, nil, "unreachable")
+	})
+
+	test.Panics(func() {
+		PanicIf(true, nil, "invariant violated")
+	})
+}
@@ -0,0 +1,69 @@
+package karma
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RedactPII enables redaction of context values marked by WithPII() and
+// DescribePII(). Redacted values are rendered as [PII] both in string and
+// JSON representations.
+var RedactPII = false
+
+const redactedPII = "[PII]"
+
+// piiValue is a context value, which contains personally identifiable
+// information.
+type piiValue struct {
+	value interface{}
+}
+
+// String returns string representation of value or [PII] if RedactPII is
+// set.
+func (value piiValue) String() string {
+	if RedactPII {
+		return redactedPII
+	}
+
+	return fmt.Sprint(value.value)
+}
+
+// MarshalJSON marshals value as is or [PII] string if RedactPII is set.
+func (value piiValue) MarshalJSON() ([]byte, error) {
+	if RedactPII {
+		return json.Marshal(redactedPII)
+	}
+
+	return json.Marshal(value.value)
+}
+
+// unwrapPII returns original value if given value is marked as PII.
+func unwrapPII(value interface{}) interface{} {
+	if pii, ok := value.(piiValue); ok {
+		return pii.value
+	}
+
+	return value
+}
+
+// WithPII returns copy of given context list with all values marked as
+// containing personally identifiable information.
+func WithPII(context *Context) *Context {
+	var result *Context
+
+	context.Walk(func(key string, value interface{}) {
+		if _, ok := value.(piiValue); !ok {
+			value = piiValue{value: value}
+		}
+
+		result = result.Describe(key, value)
+	})
+
+	return result
+}
+
+// DescribePII is the same as Describe(), but value is marked as containing
+// personally identifiable information.
+func DescribePII(key string, value interface{}) *Context {
+	return WithPII(Describe(key, value))
+}
@@ -0,0 +1,116 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribePII_RedactsValuesWhenEnabled(t *testing.T) {
+	test := assert.New(t)
+
+	err := DescribePII("email", "user@example.com").
+		Describe("host", "example.com").
+		Format(errors.New("timeout"), "unable to send")
+
+	test.EqualError(
+		err,
+		output(
+			"unable to send",
+			"├─ timeout",
+			"├─ email: user@example.com",
+			"└─ host: example.com",
+		),
+	)
+
+	RedactPII = true
+	defer func() {
+		RedactPII = false
+	}()
+
+	test.EqualError(
+		err,
+		output(
+			"unable to send",
+			"├─ timeout",
+			"├─ email: [PII]",
+			"└─ host: example.com",
+		),
+	)
+
+	test.Equal(
+		`{"reason":"timeout","message":"unable to send","context":[`+
+			`{"key":"email","value":"[PII]"},`+
+			`{"key":"host","value":"example.com"}]}`,
+		JSON(err),
+	)
+}
+
+func TestWithPII_MarksAllPairs(t *testing.T) {
+	test := assert.New(t)
+
+	context := WithPII(Describe("email", "user@example.com").Describe("age", 42))
+
+	err := WithPII(context).Describe("host", "example.com").Reason("timeout")
+
+	test.Equal(
+		`{"reason":"timeout","context":[`+
+			`{"key":"email","value":"user@example.com"},`+
+			`{"key":"age","value":42},`+
+			`{"key":"host","value":"example.com"}]}`,
+		JSON(err),
+	)
+
+	RedactPII = true
+	defer func() {
+		RedactPII = false
+	}()
+
+	test.EqualError(
+		err,
+		output(
+			"timeout",
+			"├─ email: [PII]",
+			"├─ age: [PII]",
+			"└─ host: example.com",
+		),
+	)
+}
+
+func TestDescribePII_ReturnsOriginalValueOnLookup(t *testing.T) {
+	test := assert.New(t)
+
+	RedactPII = true
+	defer func() {
+		RedactPII = false
+	}()
+
+	err := Format(
+		DescribePII("email", "root@example.com").
+			Format(errors.New("denied"), "unable to login"),
+		"unable to authorize",
+	)
+
+	email, ok := GetContextValueAs[string](err.GetReasons()[0].(Karma), "email")
+	test.True(ok)
+	test.Equal("root@example.com", email)
+
+	value, ok := GetContextValueDeep(err, "email")
+	test.True(ok)
+	test.Equal("root@example.com", value)
+
+	typed, ok := GetContextValue(err, ContextKey[string]{"email"})
+	test.True(ok)
+	test.Equal("root@example.com", typed)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to authorize",
+			"└─ unable to login",
+			"   ├─ denied",
+			"   └─ email: [PII]",
+		),
+	)
+}
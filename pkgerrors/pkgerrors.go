@@ -0,0 +1,186 @@
+// Package pkgerrors provides drop-in replacement for github.com/pkg/errors
+// functions, backed by karma hierarchical errors.
+//
+// Use it to migrate existing codebase from pkg/errors to karma:
+//
+//	import errors "github.com/reconquest/karma-go/pkgerrors"
+//
+// Stack traces recorded by pkg/errors are preserved and attached to the
+// karma context under the "stack" key.
+package pkgerrors // import "github.com/reconquest/karma-go/pkgerrors"
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/reconquest/karma-go"
+)
+
+// StackKey is a context key which is used to store stack trace.
+const StackKey = "stack"
+
+type causer interface {
+	Cause() error
+}
+
+// New returns an error with the supplied message.
+func New(message string) error {
+	return karma.Format(nil, "%s", message)
+}
+
+// Errorf formats according to a format specifier and returns the string
+// as a value that satisfies error.
+func Errorf(format string, args ...interface{}) error {
+	return karma.Format(nil, format, args...)
+}
+
+// Wrap returns an error annotating err with message. If err is nil, Wrap
+// returns nil.
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+
+	return describeStack(err).Format(err, "%s", message)
+}
+
+// Wrapf returns an error annotating err with the format specifier. If err is
+// nil, Wrapf returns nil.
+func Wrapf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	return describeStack(err).Format(err, format, args...)
+}
+
+// WithMessage annotates err with a new message. If err is nil, WithMessage
+// returns nil.
+func WithMessage(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+
+	return describeStack(err).Format(err, "%s", message)
+}
+
+// WithStack annotates err with a stack trace at the point WithStack was
+// called, unless err already has stack trace recorded by pkg/errors. If err
+// is nil, WithStack returns nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	context := describeStack(err)
+	if context == nil {
+		context = karma.Describe(StackKey, callers())
+	}
+
+	return context.Reason(err)
+}
+
+// Cause returns the underlying cause of the error. Both karma hierarchical
+// errors and errors implementing pkg/errors causer interface are traversed.
+func Cause(err error) error {
+	for {
+		if _, ok := err.(karma.Hierarchical); ok {
+			err = karma.Cause(err)
+			if _, ok := err.(karma.Hierarchical); ok {
+				return err
+			}
+		}
+
+		cause, ok := err.(causer)
+		if !ok {
+			return err
+		}
+
+		err = cause.Cause()
+	}
+}
+
+func describeStack(err error) *karma.Context {
+	stack := extractStack(err)
+	if stack == "" {
+		return nil
+	}
+
+	return karma.Describe(StackKey, stack)
+}
+
+// extractStack finds the first error in the chain, which has StackTrace()
+// method returning slice of program counters, like pkg/errors does, and
+// formats it. Reflection is used to avoid dependency on pkg/errors.
+func extractStack(err error) string {
+	for err != nil {
+		if _, ok := err.(karma.Hierarchical); ok {
+			return ""
+		}
+
+		method := reflect.ValueOf(err).MethodByName("StackTrace")
+		if method.IsValid() &&
+			method.Type().NumIn() == 0 &&
+			method.Type().NumOut() == 1 &&
+			method.Type().Out(0).Kind() == reflect.Slice &&
+			method.Type().Out(0).Elem().Kind() == reflect.Uintptr {
+			trace := method.Call(nil)[0]
+
+			frames := make([]uintptr, trace.Len())
+			for i := 0; i < trace.Len(); i++ {
+				frames[i] = uintptr(trace.Index(i).Uint())
+			}
+
+			return formatStack(frames)
+		}
+
+		switch typed := err.(type) {
+		case causer:
+			err = typed.Cause()
+		case interface{ Unwrap() error }:
+			err = typed.Unwrap()
+		default:
+			return ""
+		}
+	}
+
+	return ""
+}
+
+func callers() string {
+	frames := make([]uintptr, 32)
+
+	// skip runtime.Callers, callers and WithStack
+	count := runtime.Callers(3, frames)
+
+	return formatStack(frames[:count])
+}
+
+// formatStack formats program counters the same way as pkg/errors does with
+// %+v verb. Program counters are expected to be return addresses, as
+// returned by runtime.Callers.
+func formatStack(frames []uintptr) string {
+	lines := []string{}
+
+	for _, frame := range frames {
+		pc := frame - 1
+
+		function := runtime.FuncForPC(pc)
+		if function == nil {
+			lines = append(lines, "unknown")
+			continue
+		}
+
+		file, line := function.FileLine(pc)
+
+		lines = append(
+			lines,
+			function.Name(),
+			fmt.Sprintf("\t%s:%d", file, line),
+		)
+	}
+
+	return strings.Join(lines, "\n")
+}
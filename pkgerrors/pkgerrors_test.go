@@ -0,0 +1,110 @@
+package pkgerrors
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/reconquest/karma-go"
+	"github.com/stretchr/testify/assert"
+)
+
+type frame uintptr
+
+type stackTrace []frame
+
+// stackError mimics pkg/errors errors with recorded stack trace.
+type stackError struct {
+	message string
+	stack   []uintptr
+}
+
+func newStackError(message string) error {
+	stack := make([]uintptr, 1)
+	runtime.Callers(1, stack)
+
+	return stackError{message: message, stack: stack}
+}
+
+func (err stackError) Error() string {
+	return err.message
+}
+
+func (err stackError) StackTrace() stackTrace {
+	trace := make(stackTrace, len(err.stack))
+	for i, pc := range err.stack {
+		trace[i] = frame(pc)
+	}
+
+	return trace
+}
+
+type causeError struct {
+	cause error
+}
+
+func (err causeError) Error() string {
+	return "cause: " + err.cause.Error()
+}
+
+func (err causeError) Cause() error {
+	return err.cause
+}
+
+func TestWrap_ReturnsNilForNilError(t *testing.T) {
+	test := assert.New(t)
+
+	test.NoError(Wrap(nil, "message"))
+	test.NoError(Wrapf(nil, "message %d", 1))
+	test.NoError(WithMessage(nil, "message"))
+	test.NoError(WithStack(nil))
+}
+
+func TestWrap_CreatesHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		Wrapf(io.EOF, "unable to read %s", "file"),
+		"unable to read file\n└─ EOF",
+	)
+}
+
+func TestWrap_ExtractsStackTrace(t *testing.T) {
+	test := assert.New(t)
+
+	err := Wrap(newStackError("broken"), "unable to read")
+
+	stack, ok := err.(karma.Karma).GetContext().GetKeyValuePairs()[1].(string)
+	test.True(ok)
+	test.Contains(stack, "newStackError")
+	test.Contains(stack, "pkgerrors_test.go")
+}
+
+func TestWithStack_RecordsStackTrace(t *testing.T) {
+	test := assert.New(t)
+
+	err := WithStack(io.EOF)
+
+	test.True(errors.Is(err, io.EOF))
+
+	stack, ok := err.(karma.Karma).GetContext().GetKeyValuePairs()[1].(string)
+	test.True(ok)
+	test.Contains(stack, "TestWithStack_RecordsStackTrace")
+}
+
+func TestCause_ReturnsRootCause(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(io.EOF, Cause(Wrap(Wrap(io.EOF, "a"), "b")))
+	test.Equal(io.EOF, Cause(Wrap(causeError{io.EOF}, "a")))
+	test.Equal(io.EOF, Cause(causeError{Wrap(io.EOF, "a")}))
+	test.Nil(Cause(nil))
+}
+
+func TestNew_CreatesError(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(New("100%"), "100%")
+	test.EqualError(Errorf("%d%%", 100), "100%")
+}
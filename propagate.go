@@ -0,0 +1,48 @@
+package karma
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+const (
+	// PropagatedAtKey is a context key which is used to store time when
+	// message was propagated.
+	PropagatedAtKey = "_propagated_at"
+
+	// PropagatingGoroutineKey is a context key which is used to store ID of
+	// goroutine, which propagated message.
+	PropagatingGoroutineKey = "_propagating_goroutine"
+)
+
+// Propagate marks given error as re-raised by adding current time and ID of
+// current goroutine to its context. No new nesting level is created.
+//
+// It is useful when error is passed to another goroutine, e.g. through
+// channel.
+func Propagate(err error) Karma {
+	return Describe(PropagatedAtKey, time.Now()).
+		Describe(PropagatingGoroutineKey, getGoroutineID()).
+		Reason(err)
+}
+
+// getGoroutineID parses ID of current goroutine from the stack trace header,
+// which looks like `goroutine 18 [running]:`.
+func getGoroutineID() uint64 {
+	buffer := make([]byte, 64)
+	buffer = buffer[:runtime.Stack(buffer, false)]
+
+	buffer = bytes.TrimPrefix(buffer, []byte("goroutine "))
+	if index := bytes.IndexByte(buffer, ' '); index >= 0 {
+		buffer = buffer[:index]
+	}
+
+	id, err := strconv.ParseUint(string(buffer), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
@@ -0,0 +1,52 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropagate_AnnotatesHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		errors.New("connection refused"),
+		"unable to connect",
+	)
+
+	propagated := make(chan Karma)
+
+	before := time.Now()
+
+	go func() {
+		propagated <- Propagate(err)
+	}()
+
+	result := <-propagated
+
+	test.Equal("unable to connect", result.Message)
+	test.Equal(err.Reason, result.Reason)
+
+	values := result.GetContext().GetKeyValuePairs()
+	test.Len(values, 6)
+	test.Equal([]interface{}{"host", "example.com"}, values[:2])
+
+	test.Equal(PropagatedAtKey, values[2])
+	test.WithinDuration(before, values[3].(time.Time), time.Minute)
+
+	test.Equal(PropagatingGoroutineKey, values[4])
+	test.NotZero(values[5])
+	test.NotEqual(getGoroutineID(), values[5])
+}
+
+func TestPropagate_AnnotatesNonHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	result := Propagate(errors.New("connection refused"))
+
+	test.Equal("", result.Message)
+	test.EqualError(result.Reason.(error), "connection refused")
+	test.Len(result.GetContext().GetKeyValuePairs(), 4)
+}
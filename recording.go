@@ -0,0 +1,154 @@
+package karma
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+)
+
+// formatRecord represents single recorded Format() call. Context values are
+// not recorded, so recording doesn't leak sensitive data.
+type formatRecord struct {
+	Message     string   `json:"message"`
+	ContextKeys []string `json:"context_keys,omitempty"`
+	ReasonType  string   `json:"reason_type,omitempty"`
+}
+
+type recorder struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	seen    map[string]struct{}
+}
+
+var globalRecorder atomic.Pointer[recorder]
+
+// StartRecording starts recording of every unique combination of message
+// format, context keys and reason type passed to Format() and
+// Context.Format(). Records are written to w as JSON lines and can be turned
+// into test stubs by GenerateTests().
+func StartRecording(w io.Writer) {
+	globalRecorder.Store(&recorder{
+		encoder: json.NewEncoder(w),
+		seen:    map[string]struct{}{},
+	})
+}
+
+// StopRecording stops recording started by StartRecording().
+func StopRecording() {
+	globalRecorder.Store(nil)
+}
+
+func recordFormat(context *Context, reason Reason, message string) {
+	recorder := globalRecorder.Load()
+	if recorder == nil {
+		return
+	}
+
+	record := formatRecord{
+		Message: message,
+	}
+
+	context.walkEager(func(key string, _ interface{}) {
+		record.ContextKeys = append(record.ContextKeys, key)
+	})
+
+	if reason != nil {
+		record.ReasonType = fmt.Sprintf("%T", reason)
+	}
+
+	id := fmt.Sprintf(
+		"%q %q %q",
+		record.Message,
+		record.ContextKeys,
+		record.ReasonType,
+	)
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if _, ok := recorder.seen[id]; ok {
+		return
+	}
+
+	recorder.seen[id] = struct{}{}
+
+	_ = recorder.encoder.Encode(record)
+}
+
+var formatVerbRegexp = regexp.MustCompile(`%[-+# 0-9.*\[\]]*[a-zA-Z%]`)
+
+// GenerateTests generates source code of Go test stubs for every record
+// written by StartRecording(). Lines which can't be parsed are skipped.
+// Generated code is already gofmt-formatted, so go/format is not imported.
+func GenerateTests(recorded io.Reader, pkgName string) string {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "package %s\n\nimport \"testing\"\n", pkgName)
+
+	names := map[string]int{}
+
+	scanner := bufio.NewScanner(recorded)
+	for scanner.Scan() {
+		var record formatRecord
+
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			continue
+		}
+
+		name := "TestFormat_" + testNameFromMessage(record.Message)
+
+		names[name]++
+		if names[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, names[name])
+		}
+
+		fmt.Fprintf(&buffer, "\nfunc %s(t *testing.T) {\n", name)
+		fmt.Fprintf(&buffer, "\t// message: %q\n", record.Message)
+
+		if len(record.ContextKeys) > 0 {
+			fmt.Fprintf(
+				&buffer,
+				"\t// context keys: %s\n",
+				strings.Join(record.ContextKeys, ", "),
+			)
+		}
+
+		if record.ReasonType != "" {
+			fmt.Fprintf(&buffer, "\t// reason type: %s\n", record.ReasonType)
+		}
+
+		fmt.Fprintf(&buffer, "\tt.Skip(\"not implemented\")\n}\n")
+	}
+
+	return buffer.String()
+}
+
+func testNameFromMessage(message string) string {
+	words := strings.FieldsFunc(
+		formatVerbRegexp.ReplaceAllString(message, " "),
+		func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		},
+	)
+
+	if len(words) == 0 {
+		return "Message"
+	}
+
+	for index, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+
+		words[index] = string(runes)
+	}
+
+	return strings.Join(words, "")
+}
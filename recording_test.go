@@ -0,0 +1,82 @@
+package karma
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartRecording_RecordsUniqueFormatCalls(t *testing.T) {
+	test := assert.New(t)
+
+	var buffer bytes.Buffer
+
+	StartRecording(&buffer)
+
+	for _, host := range []string{"example.com", "example.org"} {
+		Describe("host", host).Describe("port", 80).Format(
+			errors.New("timeout"),
+			"unable to connect to %s",
+			host,
+		)
+	}
+
+	Format(nil, "no reason")
+
+	StopRecording()
+
+	Format(nil, "not recorded")
+
+	test.Equal(
+		output(
+			`{"message":"unable to connect to %s",`+
+				`"context_keys":["host","port"],`+
+				`"reason_type":"*errors.errorString"}`,
+			`{"message":"no reason"}`,
+			``,
+		),
+		buffer.String(),
+	)
+}
+
+func TestGenerateTests_GeneratesTestStubs(t *testing.T) {
+	test := assert.New(t)
+
+	recorded := bytes.NewBufferString(output(
+		`{"message":"unable to connect to %s",`+
+			`"context_keys":["host","port"],`+
+			`"reason_type":"*errors.errorString"}`,
+		`invalid line`,
+		`{"message":"unable to connect to %q"}`,
+		`{"message":"%v"}`,
+	))
+
+	test.Equal(
+		output(
+			`package app`,
+			``,
+			`import "testing"`,
+			``,
+			`func TestFormat_UnableToConnectTo(t *testing.T) {`,
+			`	// message: "unable to connect to %s"`,
+			`	// context keys: host, port`,
+			`	// reason type: *errors.errorString`,
+			`	t.Skip("not implemented")`,
+			`}`,
+			``,
+			`func TestFormat_UnableToConnectTo_2(t *testing.T) {`,
+			`	// message: "unable to connect to %q"`,
+			`	t.Skip("not implemented")`,
+			`}`,
+			``,
+			`func TestFormat_Message(t *testing.T) {`,
+			`	// message: "%v"`,
+			`	t.Skip("not implemented")`,
+			`}`,
+			``,
+		),
+		GenerateTests(recorded, "app"),
+	)
+}
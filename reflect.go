@@ -1,25 +1,144 @@
 package karma
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 )
 
-func DescribeDeep(prefixKey string, obj interface{}) *Context {
+// DescribeDeepOptions controls how DescribeDeep describes values.
+type DescribeDeepOptions struct {
+	// Stringer enables using String() method for values implementing
+	// fmt.Stringer instead of describing their fields.
+	Stringer bool
+
+	// TextMarshaler enables using MarshalText() method for values
+	// implementing encoding.TextMarshaler instead of describing their
+	// fields.
+	TextMarshaler bool
+
+	// ExpandInterfaces enables describing of concrete values stored in
+	// interfaces and maps, instead of formatting them using fmt.Sprint().
+	ExpandInterfaces bool
+
+	// MaxInterfaceDepth limits number of interfaces, which will be
+	// dereferenced in chain of pointers and interfaces, when
+	// ExpandInterfaces is set. Zero means default limit of 10.
+	MaxInterfaceDepth int
+
+	// PromoteEmbedded enables describing fields of embedded structs as fields
+	// of parent struct, without embedded type name in key. Embedded types,
+	// which are not structs or pointers to structs, are described as usual
+	// fields.
+	PromoteEmbedded bool
+}
+
+const defaultMaxInterfaceDepth = 10
+
+// DescribeDeepOption changes DescribeDeepOptions.
+type DescribeDeepOption func(*DescribeDeepOptions)
+
+// WithStringer makes DescribeDeep use String() method of values, which
+// implement fmt.Stringer.
+func WithStringer() DescribeDeepOption {
+	return func(options *DescribeDeepOptions) {
+		options.Stringer = true
+	}
+}
+
+// WithTextMarshaler makes DescribeDeep use MarshalText() method of values,
+// which implement encoding.TextMarshaler. If marshaling fails, error will be
+// used as value.
+func WithTextMarshaler() DescribeDeepOption {
+	return func(options *DescribeDeepOptions) {
+		options.TextMarshaler = true
+	}
+}
+
+// WithExpandInterfaces makes DescribeDeep describe concrete values stored in
+// interfaces and maps. Chains of pointers and interfaces are dereferenced up
+// to maxDepth interfaces, zero means default limit.
+func WithExpandInterfaces(maxDepth int) DescribeDeepOption {
+	return func(options *DescribeDeepOptions) {
+		options.ExpandInterfaces = true
+		options.MaxInterfaceDepth = maxDepth
+	}
+}
+
+// WithPromoteEmbedded makes DescribeDeep describe fields of embedded structs
+// as fields of parent struct.
+func WithPromoteEmbedded() DescribeDeepOption {
+	return func(options *DescribeDeepOptions) {
+		options.PromoteEmbedded = true
+	}
+}
+
+func DescribeDeep(
+	prefixKey string,
+	obj interface{},
+	options ...DescribeDeepOption,
+) *Context {
+	var opts DescribeDeepOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
 	ctx := &Context{}
-	describeDeep(ctx, obj, prefixKey, "")
+	describeDeep(ctx, obj, prefixKey, "", &opts, map[uintptr]bool{})
 	return ctx
 }
 
-func describeDeep(ctx *Context, obj interface{}, prefix string, key string) {
-	resource := reflect.Indirect(reflect.ValueOf(obj))
+func describeDeep(
+	ctx *Context,
+	obj interface{},
+	prefix string,
+	key string,
+	options *DescribeDeepOptions,
+	visited map[uintptr]bool,
+) {
+	prefixKey := joinPrefixKey(prefix, key)
+
+	if value, ok := describeMarshaler(obj, options); ok {
+		*ctx = *ctx.Describe(prefixKey, value)
+		return
+	}
+
+	resource := reflect.ValueOf(obj)
+
+	interfaces := 0
+	for resource.Kind() == reflect.Ptr ||
+		isExpandable(resource, options, interfaces) {
+		if resource.IsNil() {
+			*ctx = *ctx.Describe(prefixKey, "<nil>")
+			return
+		}
+
+		if resource.Kind() == reflect.Interface {
+			interfaces++
+			resource = resource.Elem()
+			continue
+		}
+
+		// pointers of current path are tracked to detect circular
+		// references
+		address := resource.Pointer()
+		if visited[address] {
+			*ctx = *ctx.Describe(prefixKey, "<circular reference>")
+			return
+		}
+
+		visited[address] = true
+		defer delete(visited, address)
 
-	for resource.Kind() == reflect.Ptr {
 		resource = resource.Elem()
 	}
 
-	prefixKey := joinPrefixKey(prefix, key)
+	if !resource.IsValid() {
+		*ctx = *ctx.Describe(prefixKey, fmt.Sprint(obj))
+		return
+	}
 
 	resourceType := resource.Type()
 	switch resource.Kind() {
@@ -31,23 +150,113 @@ func describeDeep(ctx *Context, obj interface{}, prefix string, key string) {
 			}
 			structField := resourceType.Field(index)
 			fieldName := string(structField.Name)
-			describeDeep(ctx, resourceField.Interface(), prefixKey, fieldName)
+			if structField.Anonymous && options.PromoteEmbedded &&
+				isStruct(structField.Type) {
+				fieldName = ""
+			}
+			describeDeep(
+				ctx,
+				resourceField.Interface(),
+				prefixKey,
+				fieldName,
+				options,
+				visited,
+			)
 		}
 	case reflect.Slice:
 		for i := 0; i < resource.Len(); i++ {
-			field := reflect.Indirect(resource.Index(i))
+			field := resource.Index(i)
 			if !field.CanInterface() {
 				continue
 			}
-			describeDeep(ctx, field.Interface(), prefixKey, "["+strconv.Itoa(i)+"]")
+			describeDeep(
+				ctx,
+				field.Interface(),
+				prefixKey,
+				"["+strconv.Itoa(i)+"]",
+				options,
+				visited,
+			)
+		}
+
+	case reflect.Map:
+		if !options.ExpandInterfaces {
+			*ctx = *ctx.Describe(prefixKey, fmt.Sprint(resource.Interface()))
+			break
+		}
+
+		keys := resource.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+
+		for _, key := range keys {
+			describeDeep(
+				ctx,
+				resource.MapIndex(key).Interface(),
+				prefixKey,
+				"["+fmt.Sprint(key)+"]",
+				options,
+				visited,
+			)
 		}
 
 	default:
-		*ctx = *ctx.Describe(prefixKey, fmt.Sprint(obj))
+		*ctx = *ctx.Describe(prefixKey, fmt.Sprint(resource.Interface()))
 	}
 
 }
 
+func isExpandable(
+	value reflect.Value,
+	options *DescribeDeepOptions,
+	interfaces int,
+) bool {
+	if !options.ExpandInterfaces || value.Kind() != reflect.Interface {
+		return false
+	}
+
+	maxDepth := options.MaxInterfaceDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxInterfaceDepth
+	}
+
+	return interfaces < maxDepth
+}
+
+func describeMarshaler(
+	obj interface{},
+	options *DescribeDeepOptions,
+) (string, bool) {
+	if !options.Stringer && !options.TextMarshaler {
+		return "", false
+	}
+
+	value := reflect.ValueOf(obj)
+	if !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+		return "", false
+	}
+
+	if options.TextMarshaler {
+		if marshaler, ok := obj.(encoding.TextMarshaler); ok {
+			text, err := marshaler.MarshalText()
+			if err != nil {
+				return fmt.Sprintf("unable to marshal text: %s", err), true
+			}
+
+			return string(text), true
+		}
+	}
+
+	if options.Stringer {
+		if stringer, ok := obj.(fmt.Stringer); ok {
+			return stringer.String(), true
+		}
+	}
+
+	return "", false
+}
+
 func joinPrefixKey(prefix string, key string) string {
 	result := prefix
 	if key != "" {
@@ -60,3 +269,12 @@ func joinPrefixKey(prefix string, key string) string {
 
 	return result
 }
+
+// isStruct returns true if given type is struct or pointer to struct.
+func isStruct(kind reflect.Type) bool {
+	if kind.Kind() == reflect.Ptr {
+		kind = kind.Elem()
+	}
+
+	return kind.Kind() == reflect.Struct
+}
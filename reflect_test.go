@@ -3,6 +3,7 @@ package karma
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -65,3 +66,235 @@ func TestReflect(t *testing.T) {
 		chunks,
 	)
 }
+
+type testTextError struct{}
+
+func (testTextError) MarshalText() ([]byte, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func TestReflect_CanUseStringer(t *testing.T) {
+	test := assert.New(t)
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	obj := struct {
+		Time  time.Time
+		Count int
+	}{
+		Time:  now,
+		Count: 1,
+	}
+
+	test.Equal(
+		[]interface{}{"obj.Count", "1"},
+		DescribeDeep("obj", obj).GetKeyValuePairs(),
+	)
+
+	test.Equal(
+		[]interface{}{
+			"obj.Time", "2020-01-02 03:04:05 +0000 UTC",
+			"obj.Count", "1",
+		},
+		DescribeDeep("obj", obj, WithStringer()).GetKeyValuePairs(),
+	)
+}
+
+func TestReflect_CanUseTextMarshaler(t *testing.T) {
+	test := assert.New(t)
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	obj := struct {
+		Time  time.Time
+		Error testTextError
+	}{
+		Time: now,
+	}
+
+	test.Equal(
+		[]interface{}{
+			"obj.Time", "2020-01-02T03:04:05Z",
+			"obj.Error", "unable to marshal text: not supported",
+		},
+		DescribeDeep(
+			"obj",
+			obj,
+			WithStringer(),
+			WithTextMarshaler(),
+		).GetKeyValuePairs(),
+	)
+}
+
+type testNode struct {
+	Name string
+	Next *testNode
+}
+
+func TestReflect_DetectsCircularReferences(t *testing.T) {
+	test := assert.New(t)
+
+	node := &testNode{Name: "a"}
+	node.Next = &testNode{Name: "b", Next: node}
+
+	test.Equal(
+		[]interface{}{
+			"node.Name", "a",
+			"node.Next.Name", "b",
+			"node.Next.Next", "<circular reference>",
+		},
+		DescribeDeep("node", node).GetKeyValuePairs(),
+	)
+
+	self := &testNode{Name: "self"}
+	self.Next = self
+
+	test.Equal(
+		[]interface{}{
+			"node.Name", "self",
+			"node.Next", "<circular reference>",
+		},
+		DescribeDeep("node", self).GetKeyValuePairs(),
+	)
+}
+
+func TestReflect_DescribesSharedPointersAndNil(t *testing.T) {
+	test := assert.New(t)
+
+	shared := &testNode{Name: "shared"}
+
+	test.Equal(
+		[]interface{}{
+			"list[0].Name", "shared",
+			"list[0].Next", "<nil>",
+			"list[1].Name", "shared",
+			"list[1].Next", "<nil>",
+		},
+		DescribeDeep("list", []*testNode{shared, shared}).GetKeyValuePairs(),
+	)
+
+	var empty *testNode
+
+	test.Equal(
+		[]interface{}{"node", "<nil>"},
+		DescribeDeep("node", empty).GetKeyValuePairs(),
+	)
+}
+
+type testPoint struct {
+	X int
+	Y int
+}
+
+func TestReflect_CanExpandInterfaces(t *testing.T) {
+	test := assert.New(t)
+
+	values := map[string]interface{}{
+		"point": testPoint{1, 2},
+		"list":  []interface{}{"a", testPoint{3, 4}},
+	}
+
+	test.Equal(
+		[]interface{}{
+			"values", "map[list:[a {3 4}] point:{1 2}]",
+		},
+		DescribeDeep("values", values).GetKeyValuePairs(),
+	)
+
+	test.Equal(
+		[]interface{}{
+			"values[list][0]", "a",
+			"values[list][1].X", "3",
+			"values[list][1].Y", "4",
+			"values[point].X", "1",
+			"values[point].Y", "2",
+		},
+		DescribeDeep(
+			"values",
+			values,
+			WithExpandInterfaces(0),
+		).GetKeyValuePairs(),
+	)
+}
+
+func TestReflect_LimitsInterfaceDepth(t *testing.T) {
+	test := assert.New(t)
+
+	var inner interface{} = testPoint{1, 2}
+	var outer interface{} = &inner
+
+	test.Equal(
+		[]interface{}{"value", "{1 2}"},
+		DescribeDeep(
+			"value",
+			&outer,
+			WithExpandInterfaces(1),
+		).GetKeyValuePairs(),
+	)
+
+	test.Equal(
+		[]interface{}{"value.X", "1", "value.Y", "2"},
+		DescribeDeep(
+			"value",
+			&outer,
+			WithExpandInterfaces(0),
+		).GetKeyValuePairs(),
+	)
+}
+
+type TestEmbedded struct {
+	ID int
+}
+
+func TestReflect_CanPromoteEmbeddedFields(t *testing.T) {
+	test := assert.New(t)
+
+	user := struct {
+		TestEmbedded
+		Name string
+	}{
+		TestEmbedded: TestEmbedded{ID: 1},
+		Name:         "root",
+	}
+
+	test.Equal(
+		[]interface{}{
+			"user.TestEmbedded.ID", "1",
+			"user.Name", "root",
+		},
+		DescribeDeep("user", user).GetKeyValuePairs(),
+	)
+
+	test.Equal(
+		[]interface{}{
+			"user.ID", "1",
+			"user.Name", "root",
+		},
+		DescribeDeep("user", user, WithPromoteEmbedded()).GetKeyValuePairs(),
+	)
+}
+
+type TestEmbeddedID int
+
+func TestReflect_DoesNotPromoteEmbeddedScalars(t *testing.T) {
+	test := assert.New(t)
+
+	user := struct {
+		TestEmbeddedID
+		*TestEmbedded
+		Name string
+	}{
+		TestEmbeddedID: 42,
+		TestEmbedded:   &TestEmbedded{ID: 1},
+		Name:           "root",
+	}
+
+	test.Equal(
+		[]interface{}{
+			"user.TestEmbeddedID", "42",
+			"user.ID", "1",
+			"user.Name", "root",
+		},
+		DescribeDeep("user", user, WithPromoteEmbedded()).GetKeyValuePairs(),
+	)
+}
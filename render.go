@@ -0,0 +1,201 @@
+package karma
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// RenderConfig represents options of rendering hierarchical messages into
+// string and JSON.
+type RenderConfig struct {
+	// IncludeMetadata enables rendering of metadata attached by
+	// WithMetadata().
+	IncludeMetadata bool
+
+	// DeduplicateMessages enables omitting of nested levels, which message is
+	// the same as message of parent level. Reasons and context of omitted
+	// levels are rendered as part of parent level.
+	DeduplicateMessages bool
+
+	// MaxContextValueLength limits length of rendered context values in
+	// runes. Longer values are truncated and suffixed with "...". Zero value
+	// means no limit. JSON representation is not affected.
+	MaxContextValueLength int
+
+	// BranchDelimiter, BranchChainer, BranchSplitter and BranchIndent are
+	// applied to corresponding globals by UseConfig(). Zero values leave
+	// globals unchanged.
+	BranchDelimiter string
+	BranchChainer   string
+	BranchSplitter  string
+	BranchIndent    int
+}
+
+// DefaultRenderConfig is a rendering config, which is used by String() and
+// MarshalJSON() methods.
+var DefaultRenderConfig = RenderConfig{}
+
+// UseASCII sets BranchDelimiter, BranchChainer and BranchSplitter to their
+// ASCII values. Like the globals, it should not be called concurrently with
+// rendering.
+func UseASCII() {
+	BranchDelimiter = BranchDelimiterASCII
+	BranchChainer = BranchChainerASCII
+	BranchSplitter = BranchSplitterASCII
+}
+
+// UseBox restores default box-drawing values of BranchDelimiter,
+// BranchChainer and BranchSplitter.
+func UseBox() {
+	BranchDelimiter = BranchDelimiterBox
+	BranchChainer = BranchChainerBox
+	BranchSplitter = BranchSplitterBox
+}
+
+// UseConfig sets DefaultRenderConfig to given config and applies its branch
+// fields to corresponding globals.
+func UseConfig(config RenderConfig) {
+	DefaultRenderConfig = config
+
+	if config.BranchDelimiter != "" {
+		BranchDelimiter = config.BranchDelimiter
+	}
+
+	if config.BranchChainer != "" {
+		BranchChainer = config.BranchChainer
+	}
+
+	if config.BranchSplitter != "" {
+		BranchSplitter = config.BranchSplitter
+	}
+
+	if config.BranchIndent > 0 {
+		BranchIndent = config.BranchIndent
+	}
+}
+
+// truncateContextValue cuts given formatted context value according to
+// DefaultRenderConfig.MaxContextValueLength.
+func truncateContextValue(value string) string {
+	limit := DefaultRenderConfig.MaxContextValueLength
+	if limit <= 0 {
+		return value
+	}
+
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+
+	return string(runes[:limit]) + "..."
+}
+
+// deduplicateMessages replaces nested levels with the same message as given
+// message has with their reasons and context.
+func deduplicateMessages(karma Karma) Karma {
+	if karma.Message == "" {
+		return karma
+	}
+
+	var (
+		reasons    []Reason
+		context    = karma.Context
+		duplicated = false
+	)
+
+	var collect func([]Reason)
+	collect = func(nested []Reason) {
+		for _, reason := range nested {
+			child, ok := getKarma(reason)
+			if !ok || child.GetMessage() != karma.Message {
+				reasons = append(reasons, reason)
+				continue
+			}
+
+			duplicated = true
+			context = appendContext(context, child.Context)
+
+			collect(child.GetReasons())
+		}
+	}
+
+	collect(karma.GetReasons())
+
+	if !duplicated {
+		return karma
+	}
+
+	karma.Reason = joinReasons(reasons)
+	karma.Context = context
+
+	return karma
+}
+
+// Renderer writes representation of hierarchical message.
+type Renderer interface {
+	Render(writer io.Writer, karma Karma) error
+}
+
+// DefaultRenderer is a renderer, which is used by String() and Error()
+// methods.
+var DefaultRenderer Renderer = TreeRenderer{}
+
+// TreeRenderer renders message as tree of nested messages and context:
+//
+//	unable to connect
+//	├─ timeout
+//	└─ host: example.com
+type TreeRenderer struct{}
+
+// Render writes tree representation of message.
+func (TreeRenderer) Render(writer io.Writer, karma Karma) error {
+	_, err := io.WriteString(writer, renderTree(karma))
+
+	return err
+}
+
+// FlatRenderer renders message as single line, see Flatten():
+//
+//	unable to connect: timeout | host=example.com
+type FlatRenderer struct{}
+
+// Render writes single line representation of message.
+func (FlatRenderer) Render(writer io.Writer, karma Karma) error {
+	flat := Flatten(karma)
+	if flat == nil {
+		return nil
+	}
+
+	_, err := io.WriteString(writer, flat.Error())
+
+	return err
+}
+
+// JSONRenderer renders message as compact JSON, see JSON().
+type JSONRenderer struct{}
+
+// Render writes JSON representation of message.
+func (JSONRenderer) Render(writer io.Writer, karma Karma) error {
+	data, err := json.Marshal(karma)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(data)
+
+	return err
+}
+
+// LogfmtRenderer renders message as logfmt line, where message is
+// colon-joined messages of all levels and context pairs of all levels are
+// added as fields:
+//
+//	message="unable to connect: timeout" host=example.com
+type LogfmtRenderer struct{}
+
+// Render writes logfmt representation of message.
+func (LogfmtRenderer) Render(writer io.Writer, karma Karma) error {
+	_, err := io.WriteString(writer, logfmt(karma))
+
+	return err
+}
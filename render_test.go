@@ -0,0 +1,281 @@
+package karma
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderConfig_DeduplicateMessages_OmitsRepeatedLevels(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		Describe("host", "example.com").Format(
+			Format(errors.New("timeout"), "unable to connect"),
+			"unable to connect",
+		),
+		"unable to connect",
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"└─ unable to connect",
+			"   ├─ unable to connect",
+			"   │  └─ timeout",
+			"   │",
+			"   └─ host: example.com",
+		),
+	)
+
+	DefaultRenderConfig.DeduplicateMessages = true
+	defer func() {
+		DefaultRenderConfig.DeduplicateMessages = false
+	}()
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ timeout",
+			"└─ host: example.com",
+		),
+	)
+
+	test.EqualError(
+		Format(Format(errors.New("timeout"), "unable to dial"), "top"),
+		output(
+			"top",
+			"└─ unable to dial",
+			"   └─ timeout",
+		),
+	)
+}
+
+func TestRenderConfig_MaxContextValueLength_TruncatesValues(t *testing.T) {
+	test := assert.New(t)
+
+	DefaultRenderConfig.MaxContextValueLength = 5
+	defer func() {
+		DefaultRenderConfig.MaxContextValueLength = 0
+	}()
+
+	err := Describe("body", "привет, мир").Describe("host", "local").Format(
+		errors.New("timeout"),
+		"unable to connect",
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ timeout",
+			"├─ body: приве...",
+			"└─ host: local",
+		),
+	)
+
+	test.Contains(JSON(err), "привет, мир")
+}
+
+func TestRenderConfig_MaxContextValueLength_AppliesAfterFormatter(t *testing.T) {
+	test := assert.New(t)
+
+	DefaultRenderConfig.MaxContextValueLength = 4
+	defer func() {
+		DefaultRenderConfig.MaxContextValueLength = 0
+	}()
+
+	RegisterContextValueFormatter("token", func(interface{}) string {
+		return "<redacted>"
+	})
+	defer UnregisterContextValueFormatter("token")
+
+	test.EqualError(
+		Describe("token", "abc").Reason("unable to login"),
+		output(
+			"unable to login",
+			"└─ token: <red...",
+		),
+	)
+}
+
+func TestRenderers_RenderMessage(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		errors.New("timeout"),
+		"unable to connect",
+	)
+
+	for renderer, expected := range map[Renderer]string{
+		TreeRenderer{}: output(
+			"unable to connect",
+			"├─ timeout",
+			"└─ host: example.com",
+		),
+		FlatRenderer{}: "unable to connect: timeout | host=example.com",
+		JSONRenderer{}: `{"reason":"timeout","message":"unable to connect",` +
+			`"context":[{"key":"host","value":"example.com"}]}`,
+		LogfmtRenderer{}: `message="unable to connect: timeout" host=example.com`,
+	} {
+		var buffer bytes.Buffer
+
+		test.NoError(renderer.Render(&buffer, err))
+		test.Equal(expected, buffer.String(), "%T", renderer)
+	}
+}
+
+type testUppercaseRenderer struct{}
+
+func (testUppercaseRenderer) Render(writer io.Writer, karma Karma) error {
+	_, err := io.WriteString(writer, strings.ToUpper(karma.GetMessage()))
+
+	return err
+}
+
+func TestDefaultRenderer_IsUsedByString(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(errors.New("timeout"), "unable to connect")
+
+	defer func() {
+		DefaultRenderer = TreeRenderer{}
+	}()
+
+	DefaultRenderer = FlatRenderer{}
+	test.EqualError(err, "unable to connect: timeout")
+
+	DefaultRenderer = testUppercaseRenderer{}
+	test.EqualError(err, "UNABLE TO CONNECT")
+
+	DefaultRenderer = TreeRenderer{}
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"└─ timeout",
+		),
+	)
+}
+
+func TestTreeRenderer_IgnoresDefaultRendererForNestedLevels(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		[]Reason{
+			Describe("port", 5432).Format(errors.New("timeout"), "dial failed"),
+			errors.New("canceled"),
+		},
+		"unable to connect",
+	)
+
+	defer func() {
+		DefaultRenderer = TreeRenderer{}
+	}()
+
+	DefaultRenderer = JSONRenderer{}
+
+	var buffer bytes.Buffer
+
+	test.NoError(TreeRenderer{}.Render(&buffer, err))
+	test.Equal(
+		output(
+			"unable to connect",
+			"├─ dial failed",
+			"│  ├─ timeout",
+			"│  └─ port: 5432",
+			"│",
+			"├─ canceled",
+			"└─ host: example.com",
+		),
+		buffer.String(),
+	)
+
+	test.Equal(
+		output(
+			"unable to connect",
+			"├─ dial failed",
+			"│  └─ timeout",
+			"│",
+			"└─ canceled",
+		),
+		Concise(err),
+	)
+}
+
+func TestUseASCII_SetsASCIIBranches(t *testing.T) {
+	test := assert.New(t)
+
+	defer UseBox()
+
+	err := Push(
+		"unable to connect",
+		Push("unable to dial", errors.New("timeout")),
+		errors.New("refused"),
+	)
+
+	UseASCII()
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"+ unable to dial",
+			"|  \\_ timeout",
+			"|",
+			"\\_ refused",
+		),
+	)
+
+	UseBox()
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ unable to dial",
+			"│  └─ timeout",
+			"│",
+			"└─ refused",
+		),
+	)
+}
+
+func TestUseConfig_AppliesAllFields(t *testing.T) {
+	test := assert.New(t)
+
+	defer func() {
+		UseBox()
+		UseConfig(RenderConfig{BranchIndent: 3})
+	}()
+
+	UseConfig(RenderConfig{
+		MaxContextValueLength: 3,
+		BranchDelimiter:       BranchDelimiterASCII,
+		BranchSplitter:        BranchSplitterASCII,
+	})
+
+	test.Equal(3, DefaultRenderConfig.MaxContextValueLength)
+	test.Equal(BranchDelimiterASCII, BranchDelimiter)
+	test.Equal(BranchChainerBox, BranchChainer)
+	test.Equal(BranchSplitterASCII, BranchSplitter)
+	test.Equal(3, BranchIndent)
+
+	test.EqualError(
+		Describe("host", "example.com").Format(
+			errors.New("timeout"),
+			"unable to connect",
+		),
+		output(
+			"unable to connect",
+			"+ timeout",
+			"\\_ host: exa...",
+		),
+	)
+}
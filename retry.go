@@ -0,0 +1,119 @@
+package karma
+
+import (
+	"time"
+)
+
+const (
+	// RetryAttemptsKey is a context key which is used to store number of
+	// performed attempts.
+	RetryAttemptsKey = "_retry_attempts"
+
+	// RetryMaxKey is a context key which is used to store maximum number of
+	// attempts.
+	RetryMaxKey = "_retry_max"
+
+	// RetryLastIntervalKey is a context key which is used to store last
+	// interval between attempts.
+	RetryLastIntervalKey = "_retry_last_interval"
+)
+
+// WithRetryInfo returns copy of given message with retry information added to
+// its context.
+func WithRetryInfo(
+	err Karma,
+	attempts int,
+	maxAttempts int,
+	lastInterval time.Duration,
+) Karma {
+	return err.
+		Annotate(RetryAttemptsKey, attempts).
+		Annotate(RetryMaxKey, maxAttempts).
+		Annotate(RetryLastIntervalKey, lastInterval)
+}
+
+// GetRetryInfo returns retry information previously associated with error
+// using WithRetryInfo(). Information is preserved through JSON serialization.
+func GetRetryInfo(
+	err error,
+) (attempts, maxAttempts int, lastInterval time.Duration, ok bool) {
+	value, ok := findContextValue(err, RetryAttemptsKey)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	attemptsValue, ok := contextInt(value)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	value, ok = findContextValue(err, RetryMaxKey)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	maxValue, ok := contextInt(value)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	value, ok = findContextValue(err, RetryLastIntervalKey)
+	if !ok {
+		return 0, 0, 0, false
+	}
+
+	interval, ok := value.(time.Duration)
+	if !ok {
+		nanoseconds, ok := contextInt(value)
+		if !ok {
+			return 0, 0, 0, false
+		}
+
+		interval = time.Duration(nanoseconds)
+	}
+
+	return int(attemptsValue), int(maxValue), interval, true
+}
+
+// WithRetry creates new hierarchical message with message of given error as
+// top-level message, given error as the first reason and errors of all
+// attempts as sibling reasons. Each attempt reason is annotated with its
+// number, starting from 1, and total number of attempts is added to
+// top-level context. Nil attempt errors are skipped.
+//
+// If err is hierarchical message, attempts are added to its reasons instead.
+// If err is nil, "all attempts failed" is used as top-level message.
+func WithRetry(err error, attempts []error) Karma {
+	reasons := []Reason{}
+
+	for i, attempt := range attempts {
+		if attempt == nil {
+			continue
+		}
+
+		reasons = append(reasons, Describe("attempt", i+1).Reason(attempt))
+	}
+
+	var result Karma
+
+	if karma, ok := getKarma(err); ok {
+		previous := karma.GetReasons()
+
+		result = *karma
+		result.Reason = joinReasons(
+			append(previous[:len(previous):len(previous)], reasons...),
+		)
+	} else if err != nil {
+		result = Karma{
+			Message: err.Error(),
+			Reason:  joinReasons(append([]Reason{err}, reasons...)),
+		}
+	} else {
+		result = Karma{
+			Message: "all attempts failed",
+			Reason:  joinReasons(reasons),
+		}
+	}
+
+	return result.Annotate("total_attempts", len(attempts))
+}
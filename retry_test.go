@@ -0,0 +1,170 @@
+package karma
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRetryInfo_AddsRetryInfoToContext(t *testing.T) {
+	test := assert.New(t)
+
+	err := WithRetryInfo(
+		Format(errors.New("connection refused"), "unable to connect"),
+		3, 5, 2*time.Second,
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ connection refused",
+			"├─ _retry_attempts: 3",
+			"├─ _retry_max: 5",
+			"└─ _retry_last_interval: 2s",
+		),
+	)
+
+	attempts, maxAttempts, interval, ok := GetRetryInfo(Format(err, "top"))
+	test.True(ok)
+	test.Equal(3, attempts)
+	test.Equal(5, maxAttempts)
+	test.Equal(2*time.Second, interval)
+}
+
+func TestGetRetryInfo_SurvivesJSON(t *testing.T) {
+	test := assert.New(t)
+
+	data, err := json.Marshal(
+		WithRetryInfo(Format(nil, "unable to connect"), 3, 5, time.Second),
+	)
+	test.NoError(err)
+
+	var restored Karma
+	test.NoError(json.Unmarshal(data, &restored))
+
+	attempts, maxAttempts, interval, ok := GetRetryInfo(restored)
+	test.True(ok)
+	test.Equal(3, attempts)
+	test.Equal(5, maxAttempts)
+	test.Equal(time.Second, interval)
+}
+
+func TestGetRetryInfo_ReturnsFalseWithoutRetryInfo(t *testing.T) {
+	test := assert.New(t)
+
+	_, _, _, ok := GetRetryInfo(Format(nil, "unable to connect"))
+	test.False(ok)
+
+	_, _, _, ok = GetRetryInfo(errors.New("unable to connect"))
+	test.False(ok)
+}
+
+func TestWithRetry_AddsAttemptsAsReasons(t *testing.T) {
+	test := assert.New(t)
+
+	failed := errors.New("all attempts failed")
+
+	err := WithRetry(
+		failed,
+		[]error{
+			errors.New("timeout"),
+			nil,
+			Format(errors.New("refused"), "unable to dial"),
+		},
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"all attempts failed",
+			"├─ all attempts failed",
+			"├─ timeout",
+			"│  └─ attempt: 1",
+			"│",
+			"├─ unable to dial",
+			"│  ├─ refused",
+			"│  └─ attempt: 3",
+			"│",
+			"└─ total_attempts: 3",
+		),
+	)
+
+	attempt, ok := GetContextValueDeep(err.GetReasons()[2].(Karma), "attempt")
+	test.True(ok)
+	test.Equal(3, attempt)
+
+	test.True(errors.Is(err, failed))
+}
+
+func TestWithRetry_KeepsContextOfHierarchicalError(t *testing.T) {
+	test := assert.New(t)
+
+	err := WithRetry(
+		Describe("host", "example.com").Format(nil, "unable to connect"),
+		[]error{errors.New("timeout")},
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ timeout",
+			"│  └─ attempt: 1",
+			"│",
+			"├─ host: example.com",
+			"└─ total_attempts: 1",
+		),
+	)
+}
+
+func TestWithRetry_UsesMessageOfError(t *testing.T) {
+	test := assert.New(t)
+
+	failed := errors.New("all attempts failed")
+
+	err := WithRetry(failed, []error{errors.New("timeout")})
+
+	test.Equal("all attempts failed", err.GetMessage())
+	test.EqualError(
+		Flatten(err),
+		"all attempts failed: all attempts failed: timeout | "+
+			"total_attempts=1 attempt=1",
+	)
+	test.True(errors.Is(err, failed))
+
+	data, marshalErr := json.Marshal(err)
+	test.NoError(marshalErr)
+	test.JSONEq(
+		`{"message":"all attempts failed","reason":[`+
+			`"all attempts failed",`+
+			`{"reason":"timeout",`+
+			`"context":[{"key":"attempt","value":1}]}],`+
+			`"context":[{"key":"total_attempts","value":1}]}`,
+		string(data),
+	)
+}
+
+func TestWithRetry_HandlesNilError(t *testing.T) {
+	test := assert.New(t)
+
+	err := WithRetry(nil, []error{errors.New("t1"), errors.New("t2")})
+
+	test.Equal("all attempts failed", err.GetMessage())
+	test.EqualError(
+		err,
+		output(
+			"all attempts failed",
+			"├─ t1",
+			"│  └─ attempt: 1",
+			"│",
+			"├─ t2",
+			"│  └─ attempt: 2",
+			"│",
+			"└─ total_attempts: 2",
+		),
+	)
+}
@@ -0,0 +1,96 @@
+package karma
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Sink captures errors, it is useful in tests to check which errors were
+// produced without changing error handling of application. Sink is safe for
+// concurrent use.
+type Sink struct {
+	mutex  sync.Mutex
+	errors []Karma
+}
+
+// capturer is implemented by sinks, which can be set as global sink.
+type capturer interface {
+	Capture(err error)
+}
+
+type globalCapturer struct {
+	capturer capturer
+}
+
+var globalSink atomic.Pointer[globalCapturer]
+
+// NewSink creates new empty Sink.
+func NewSink() *Sink {
+	return &Sink{}
+}
+
+// SetSink sets sink, which will capture every message created by Format()
+// and Context.Format(). Pass nil to stop capturing.
+func SetSink(sink *Sink) {
+	if sink == nil {
+		globalSink.Store(nil)
+		return
+	}
+
+	globalSink.Store(&globalCapturer{sink})
+}
+
+func captureToSink(karma Karma) {
+	if global := globalSink.Load(); global != nil {
+		global.capturer.Capture(karma)
+	}
+}
+
+// Capture stores given error in the sink. Errors which are not Karma are
+// stored as reasons of message without text. Nil errors are ignored.
+func (sink *Sink) Capture(err error) {
+	if err == nil {
+		return
+	}
+
+	karma, ok := getKarma(err)
+	if !ok {
+		karma = &Karma{Reason: err}
+	}
+
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	sink.errors = append(sink.errors, *karma)
+}
+
+// Errors returns all captured errors in order of capturing.
+func (sink *Sink) Errors() []Karma {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	result := make([]Karma, len(sink.errors))
+	copy(result, sink.errors)
+
+	return result
+}
+
+// Contains returns true if any of captured errors contains given branch.
+// See Contains() for details.
+func (sink *Sink) Contains(branch Reason) bool {
+	for _, err := range sink.Errors() {
+		if Contains(err, branch) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Clear removes all captured errors.
+func (sink *Sink) Clear() {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	sink.errors = nil
+}
@@ -0,0 +1,80 @@
+package karma
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSink_CapturesFormattedErrors(t *testing.T) {
+	test := assert.New(t)
+
+	sink := NewSink()
+
+	SetSink(sink)
+	defer SetSink(nil)
+
+	first := Format(io.EOF, "unable to read")
+	second := Describe("path", "/tmp").Format(os.ErrNotExist, "unable to open")
+
+	test.Equal([]Karma{first, second}, sink.Errors())
+	test.True(sink.Contains(io.EOF))
+	test.True(sink.Contains(os.ErrNotExist))
+	test.False(sink.Contains(os.ErrInvalid))
+
+	sink.Clear()
+
+	test.Empty(sink.Errors())
+	test.False(sink.Contains(io.EOF))
+}
+
+func TestSink_DoesNotCaptureWhenUnset(t *testing.T) {
+	test := assert.New(t)
+
+	sink := NewSink()
+
+	SetSink(sink)
+	SetSink(nil)
+
+	_ = Format(io.EOF, "unable to read")
+
+	test.Empty(sink.Errors())
+}
+
+func TestSink_CapturesNonHierarchicalErrors(t *testing.T) {
+	test := assert.New(t)
+
+	sink := NewSink()
+	sink.Capture(nil)
+	sink.Capture(errors.New("failure"))
+
+	test.Equal([]Karma{{Reason: errors.New("failure")}}, sink.Errors())
+	test.True(sink.Contains("failure"))
+}
+
+func TestSink_IsSafeForConcurrentUse(t *testing.T) {
+	test := assert.New(t)
+
+	sink := NewSink()
+
+	SetSink(sink)
+	defer SetSink(nil)
+
+	var group sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func(i int) {
+			defer group.Done()
+
+			_ = Format(nil, "failure %d", i)
+		}(i)
+	}
+
+	group.Wait()
+
+	test.Len(sink.Errors(), 100)
+}
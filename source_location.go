@@ -0,0 +1,56 @@
+package karma
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// SourceLocation represents location of code in source files.
+type SourceLocation struct {
+	// File is a full path to source file.
+	File string `json:"file"`
+
+	// Line is a line number in source file.
+	Line int `json:"line"`
+
+	// Function is a fully qualified name of function.
+	Function string `json:"function"`
+}
+
+// String returns base name of file and line number separated by colon.
+func (location SourceLocation) String() string {
+	return filepath.Base(location.File) + ":" + strconv.Itoa(location.Line)
+}
+
+// FormatHere creates new hierarchical message same as Format() and stores
+// location of code which called it in SourceLocation field. Location is
+// rendered by String() after the message.
+//
+//go:noinline
+func FormatHere(reason Reason, message string, args ...interface{}) Karma {
+	pc, file, line, ok := runtime.Caller(1)
+	if !ok {
+		return Format(reason, message, args...)
+	}
+
+	location := &SourceLocation{
+		File: file,
+		Line: line,
+	}
+
+	if function := runtime.FuncForPC(pc); function != nil {
+		location.Function = function.Name()
+	}
+
+	return formatWithDefaults(
+		nil,
+		reason,
+		message,
+		args,
+		GetDefaultContext(nil),
+		func(karma *Karma) {
+			karma.SourceLocation = location
+		},
+	)
+}
@@ -0,0 +1,75 @@
+package karma
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatHere_StoresCallerLocation(t *testing.T) {
+	test := assert.New(t)
+
+	_, _, line, _ := runtime.Caller(0)
+	err := FormatHere(errors.New("timeout"), "unable to connect")
+
+	test.NotNil(err.SourceLocation)
+	test.True(
+		strings.HasSuffix(err.SourceLocation.File, "source_location_test.go"),
+	)
+	test.Equal(line+1, err.SourceLocation.Line)
+	test.Equal(
+		"github.com/reconquest/karma-go.TestFormatHere_StoresCallerLocation",
+		err.SourceLocation.Function,
+	)
+
+	test.EqualError(
+		err,
+		output(
+			fmt.Sprintf(
+				"unable to connect [source_location_test.go:%d]",
+				line+1,
+			),
+			"└─ timeout",
+		),
+	)
+}
+
+func TestFormatHere_CapturesLocationToSink(t *testing.T) {
+	test := assert.New(t)
+
+	sink := NewSink()
+
+	SetSink(sink)
+	defer SetSink(nil)
+
+	err := FormatHere(errors.New("timeout"), "unable to connect")
+
+	test.NotNil(err.SourceLocation)
+	test.Equal([]Karma{err}, sink.Errors())
+}
+
+func TestFormatHere_MarshalsLocationToJSON(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(nil, "message")
+	err.SourceLocation = &SourceLocation{
+		File:     "/src/main.go",
+		Line:     42,
+		Function: "main.main",
+	}
+
+	test.JSONEq(
+		`{"reason":null,"message":"message","source_location":`+
+			`{"file":"/src/main.go","line":42,"function":"main.main"}}`,
+		JSON(err),
+	)
+
+	restored, unmarshalErr := NewFromJSONString(JSON(err))
+	test.NoError(unmarshalErr)
+	test.Equal(err.SourceLocation, restored.SourceLocation)
+	test.EqualError(restored, "message [main.go:42]")
+}
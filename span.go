@@ -0,0 +1,142 @@
+package karma
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	// TraceIDKey is a context key which is used to store trace ID added by
+	// AnnotateWithSpan().
+	TraceIDKey = "_trace_id"
+
+	// SpanIDKey is a context key which is used to store span ID added by
+	// AnnotateWithSpan().
+	SpanIDKey = "_span_id"
+
+	// SpanKey is a context key which is used to store string representation
+	// of span, which IDs can't be extracted by AnnotateWithSpan().
+	SpanKey = "_span"
+)
+
+// SpanExtractor returns trace ID and span ID of given span. Empty IDs mean
+// that span is not recognized by extractor.
+type SpanExtractor func(span interface{}) (traceID, spanID string)
+
+// SpanOption configures AnnotateWithSpan().
+type SpanOption func(*spanOptions)
+
+type spanOptions struct {
+	extractors []SpanExtractor
+}
+
+// WithSpanExtractor adds custom extractor, which is tried before built-in
+// ones.
+func WithSpanExtractor(
+	fn func(span interface{}) (traceID, spanID string),
+) SpanOption {
+	return func(options *spanOptions) {
+		options.extractors = append(options.extractors, fn)
+	}
+}
+
+// AnnotateWithSpan returns copy of given message with trace ID and span ID
+// of given span added to its context. OpenTelemetry spans (SpanContext()
+// method) and OpenTracing spans (Context() method) are recognized as well as
+// any span with TraceID() and SpanID() methods. If IDs can't be extracted,
+// span is added to context as string.
+func AnnotateWithSpan(err Karma, span interface{}, opts ...SpanOption) Karma {
+	if span == nil {
+		return err
+	}
+
+	options := spanOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	for _, extractor := range options.extractors {
+		traceID, spanID := extractor(span)
+		if traceID != "" || spanID != "" {
+			return annotateSpanIDs(err, traceID, spanID)
+		}
+	}
+
+	traceID, spanID, ok := extractSpanIDs(span)
+	if !ok {
+		return err.Annotate(SpanKey, fmt.Sprint(span))
+	}
+
+	return annotateSpanIDs(err, traceID, spanID)
+}
+
+func annotateSpanIDs(err Karma, traceID, spanID string) Karma {
+	if traceID != "" {
+		err = err.Annotate(TraceIDKey, traceID)
+	}
+
+	if spanID != "" {
+		err = err.Annotate(SpanIDKey, spanID)
+	}
+
+	return err
+}
+
+func extractSpanIDs(span interface{}) (string, string, bool) {
+	value := reflect.ValueOf(span)
+
+	for _, method := range []string{"SpanContext", "Context"} {
+		context, ok := callSpanMethod(value, method)
+		if !ok {
+			continue
+		}
+
+		traceID, spanID, ok := getSpanIDs(context)
+		if ok {
+			return traceID, spanID, true
+		}
+	}
+
+	return getSpanIDs(value)
+}
+
+func getSpanIDs(value reflect.Value) (string, string, bool) {
+	traceID, ok := callSpanMethod(value, "TraceID")
+	if !ok {
+		return "", "", false
+	}
+
+	spanID, ok := callSpanMethod(value, "SpanID")
+	if !ok {
+		return "", "", false
+	}
+
+	return fmt.Sprint(traceID.Interface()), fmt.Sprint(spanID.Interface()), true
+}
+
+// callSpanMethod calls method without arguments which returns single value.
+func callSpanMethod(value reflect.Value, name string) (reflect.Value, bool) {
+	if !value.IsValid() {
+		return reflect.Value{}, false
+	}
+
+	method := value.MethodByName(name)
+	if !method.IsValid() {
+		return reflect.Value{}, false
+	}
+
+	if method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return reflect.Value{}, false
+	}
+
+	result := method.Call(nil)[0]
+	if result.Kind() == reflect.Interface {
+		if result.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		result = result.Elem()
+	}
+
+	return result, true
+}
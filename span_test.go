@@ -0,0 +1,124 @@
+package karma
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTraceID [2]byte
+
+func (id testTraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+type testSpanContext struct {
+	traceID testTraceID
+	spanID  testTraceID
+}
+
+func (context testSpanContext) TraceID() testTraceID {
+	return context.traceID
+}
+
+func (context testSpanContext) SpanID() testTraceID {
+	return context.spanID
+}
+
+type testOpenTelemetrySpan struct {
+	context testSpanContext
+}
+
+func (span testOpenTelemetrySpan) SpanContext() testSpanContext {
+	return span.context
+}
+
+type testOpenTracingSpanContext interface {
+	TraceID() testTraceID
+	SpanID() testTraceID
+}
+
+type testOpenTracingSpan struct {
+	context testSpanContext
+}
+
+func (span *testOpenTracingSpan) Context() testOpenTracingSpanContext {
+	return span.context
+}
+
+func TestAnnotateWithSpan_ExtractsIDsFromKnownSpans(t *testing.T) {
+	test := assert.New(t)
+
+	context := testSpanContext{
+		traceID: testTraceID{0xab, 0xcd},
+		spanID:  testTraceID{0x01, 0x02},
+	}
+
+	for _, span := range []interface{}{
+		testOpenTelemetrySpan{context: context},
+		&testOpenTracingSpan{context: context},
+		context,
+	} {
+		err := AnnotateWithSpan(Format(nil, "unable to connect"), span)
+
+		test.EqualError(
+			err,
+			output(
+				"unable to connect",
+				"├─ _trace_id: abcd",
+				"└─ _span_id: 0102",
+			),
+		)
+	}
+}
+
+func TestAnnotateWithSpan_FallsBackToString(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(nil, "unable to connect")
+
+	test.Equal(err, AnnotateWithSpan(err, nil))
+
+	test.EqualError(
+		AnnotateWithSpan(err, "span-1"),
+		output(
+			"unable to connect",
+			"└─ _span: span-1",
+		),
+	)
+}
+
+func TestAnnotateWithSpan_UsesCustomExtractor(t *testing.T) {
+	test := assert.New(t)
+
+	extractor := WithSpanExtractor(func(span interface{}) (string, string) {
+		if span, ok := span.(string); ok {
+			return "trace-" + span, "span-" + span
+		}
+
+		return "", ""
+	})
+
+	test.EqualError(
+		AnnotateWithSpan(Format(nil, "unable to connect"), "1", extractor),
+		output(
+			"unable to connect",
+			"├─ _trace_id: trace-1",
+			"└─ _span_id: span-1",
+		),
+	)
+
+	test.EqualError(
+		AnnotateWithSpan(
+			Format(nil, "unable to connect"),
+			testSpanContext{spanID: testTraceID{0x01, 0x02}},
+			extractor,
+		),
+		output(
+			"unable to connect",
+			"├─ _trace_id: 0000",
+			"└─ _span_id: 0102",
+		),
+	)
+}
@@ -0,0 +1,124 @@
+package karma
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+const (
+	// SQLStateKey is a context key which is used to store SQLSTATE code.
+	SQLStateKey = "_sql_state"
+
+	// SQLQueryKey is a context key which is used to store SQL query.
+	SQLQueryKey = "_sql_query"
+
+	// SQLArgsKey is a context key which is used to store arguments of SQL
+	// query.
+	SQLArgsKey = "_sql_args"
+)
+
+// WithSQLState returns copy of given message with SQLSTATE code added to its
+// context.
+func WithSQLState(err Karma, state string) Karma {
+	return err.Annotate(SQLStateKey, state)
+}
+
+// GetSQLState returns SQLSTATE code previously associated with error at any
+// level of the hierarchy.
+func GetSQLState(err error) (string, bool) {
+	value, ok := findContextValue(err, SQLStateKey)
+	if !ok {
+		return "", false
+	}
+
+	state, ok := value.(string)
+
+	return state, ok
+}
+
+// WrapSQLError adds query and SQLSTATE code (if it can be extracted) to the
+// context of given database error. No new nesting level is created.
+//
+// Error code is extracted from errors, which have SQLState() method (pgx),
+// Code string field (lib/pq) or Number integer field (go-sql-driver/mysql).
+func WrapSQLError(err error, query string) Karma {
+	var context *Context
+
+	if state, ok := extractSQLState(err); ok {
+		context = context.Describe(SQLStateKey, state)
+	}
+
+	return context.Describe(SQLQueryKey, query).Reason(err)
+}
+
+// WrapSQL is the same as WrapSQLError(), but also adds query arguments to
+// the context if there are any.
+func WrapSQL(err error, query string, args ...interface{}) Karma {
+	karma := WrapSQLError(err, query)
+
+	if len(args) > 0 {
+		karma = karma.Annotate(SQLArgsKey, args)
+	}
+
+	return karma
+}
+
+// IsSQLNoRows reports whether sql.ErrNoRows is a reason of given error at any
+// level of the hierarchy.
+func IsSQLNoRows(err error) bool {
+	found := false
+
+	walk(err, 0, func(_ int, reason Reason, _ *Context) bool {
+		if _, ok := getKarma(reason); ok {
+			return true
+		}
+
+		if err, ok := reason.(error); ok && errors.Is(err, sql.ErrNoRows) {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func extractSQLState(err error) (string, bool) {
+	for err != nil {
+		if _, ok := getKarma(err); ok {
+			return GetSQLState(err)
+		}
+
+		if stater, ok := err.(interface{ SQLState() string }); ok {
+			return stater.SQLState(), true
+		}
+
+		value := reflect.Indirect(reflect.ValueOf(err))
+		if value.Kind() == reflect.Struct {
+			code := value.FieldByName("Code")
+			if code.IsValid() && code.Kind() == reflect.String {
+				return code.String(), true
+			}
+
+			number := value.FieldByName("Number")
+			if number.IsValid() {
+				switch number.Kind() {
+				case reflect.Uint, reflect.Uint8, reflect.Uint16,
+					reflect.Uint32, reflect.Uint64:
+					return fmt.Sprint(number.Uint()), true
+				}
+			}
+		}
+
+		wrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+
+		err = wrapper.Unwrap()
+	}
+
+	return "", false
+}
@@ -0,0 +1,138 @@
+package karma
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pqError mimics lib/pq error.
+type pqError struct {
+	Code    string
+	Message string
+}
+
+func (err *pqError) Error() string {
+	return "pq: " + err.Message
+}
+
+// mysqlError mimics go-sql-driver/mysql error.
+type mysqlError struct {
+	Number  uint16
+	Message string
+}
+
+func (err *mysqlError) Error() string {
+	return fmt.Sprintf("Error %d: %s", err.Number, err.Message)
+}
+
+func TestWrapSQLError_ExtractsPostgresCode(t *testing.T) {
+	test := assert.New(t)
+
+	err := WrapSQLError(
+		&pqError{Code: "23505", Message: "duplicate key"},
+		"INSERT INTO users VALUES ($1)",
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"pq: duplicate key",
+			"├─ _sql_state: 23505",
+			"└─ _sql_query: INSERT INTO users VALUES ($1)",
+		),
+	)
+
+	state, ok := GetSQLState(Format(err, "unable to create user"))
+	test.True(ok)
+	test.Equal("23505", state)
+}
+
+func TestWrapSQLError_ExtractsMySQLNumber(t *testing.T) {
+	test := assert.New(t)
+
+	err := WrapSQLError(
+		fmt.Errorf("exec: %w", &mysqlError{Number: 1062, Message: "duplicate"}),
+		"INSERT INTO users VALUES (?)",
+	)
+
+	state, ok := GetSQLState(err)
+	test.True(ok)
+	test.Equal("1062", state)
+}
+
+func TestWrapSQLError_AddsOnlyQueryForUnknownError(t *testing.T) {
+	test := assert.New(t)
+
+	err := WrapSQLError(errors.New("connection refused"), "SELECT 1")
+
+	test.EqualError(
+		err,
+		output(
+			"connection refused",
+			"└─ _sql_query: SELECT 1",
+		),
+	)
+
+	_, ok := GetSQLState(err)
+	test.False(ok)
+}
+
+func TestWithSQLState_AddsStateToContext(t *testing.T) {
+	test := assert.New(t)
+
+	state, ok := GetSQLState(WithSQLState(Format(nil, "failed"), "40001"))
+	test.True(ok)
+	test.Equal("40001", state)
+}
+
+func TestWrapSQL_AddsQueryAndArgs(t *testing.T) {
+	test := assert.New(t)
+
+	err := WrapSQL(
+		sql.ErrNoRows,
+		"SELECT * FROM users WHERE id = $1",
+		42,
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"sql: no rows in result set",
+			"├─ _sql_query: SELECT * FROM users WHERE id = $1",
+			"└─ _sql_args: [",
+			"     42",
+			"   ]",
+		),
+	)
+
+	_, ok := GetContextValueTop(WrapSQL(sql.ErrNoRows, "SELECT 1"), SQLArgsKey)
+	test.False(ok)
+}
+
+func TestIsSQLNoRows_ChecksAllLevels(t *testing.T) {
+	test := assert.New(t)
+
+	test.True(IsSQLNoRows(sql.ErrNoRows))
+	test.True(IsSQLNoRows(Format(sql.ErrNoRows, "unable to query user")))
+	test.True(errors.Is(Format(sql.ErrNoRows, "unable to query user"), sql.ErrNoRows))
+	test.True(IsSQLNoRows(
+		Format(
+			Push(
+				"unable to query",
+				errors.New("timeout"),
+				WrapSQL(
+					fmt.Errorf("scan: %w", sql.ErrNoRows),
+					"SELECT 1",
+				),
+			),
+			"unable to load user",
+		),
+	))
+
+	test.False(IsSQLNoRows(nil))
+	test.False(IsSQLNoRows(Format(errors.New("timeout"), "unable to query")))
+}
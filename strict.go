@@ -0,0 +1,100 @@
+package karma
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// FormatErrorKey is a context key which is used to describe mismatch between
+// format verbs and arguments when StrictFormatting is enabled.
+const FormatErrorKey = "_format_error"
+
+// StrictFormatting enables checking that number of format verbs in message
+// matches number of arguments passed to Format() and Context.Format(). On
+// mismatch message is kept unformatted and mismatch is described in the
+// context instead of embedding %!(MISSING) or %!(EXTRA) into message.
+var StrictFormatting = false
+
+func formatMessage(
+	context *Context,
+	message string,
+	args []interface{},
+) (string, *Context) {
+	if StrictFormatting {
+		if err := checkFormatArgs(message, args); err != nil {
+			return message, context.Describe(FormatErrorKey, err.Error())
+		}
+	}
+
+	return fmt.Sprintf(message, args...), context
+}
+
+func checkFormatArgs(message string, args []interface{}) error {
+	expected, ok := countFormatArgs(message)
+	if !ok || expected == len(args) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"format %q expects %d arguments, got %d",
+		message, expected, len(args),
+	)
+}
+
+// countFormatArgs returns number of arguments expected by format string. If
+// format uses explicit argument indexes, number can't be determined and false
+// is returned.
+func countFormatArgs(format string) (int, bool) {
+	count := 0
+
+	for index := 0; index < len(format); index++ {
+		if format[index] != '%' {
+			continue
+		}
+
+		index++
+
+		// flags
+		for index < len(format) && isFormatFlag(format[index]) {
+			index++
+		}
+
+		// width and precision
+	arguments:
+		for ; index < len(format); index++ {
+			switch char := format[index]; {
+			case char == '[':
+				return 0, false
+			case char == '*':
+				count++
+			case char == '.' || (char >= '0' && char <= '9'):
+			default:
+				break arguments
+			}
+		}
+
+		if index >= len(format) {
+			break
+		}
+
+		if format[index] == '%' {
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(format[index:])
+		index += size - 1
+
+		count++
+	}
+
+	return count, true
+}
+
+func isFormatFlag(char byte) bool {
+	switch char {
+	case '+', '-', '#', ' ', '0':
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,66 @@
+package karma
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountFormatArgs(t *testing.T) {
+	test := assert.New(t)
+
+	for format, expected := range map[string]int{
+		"":                         0,
+		"no verbs":                 0,
+		"100%%":                    0,
+		"host %s port %d":          2,
+		"%-10s|%+.2f|%#x|% d|%05d": 5,
+		"%*d %.*f":                 4,
+		"%v%":                      1,
+		"unicode %s é":             1,
+	} {
+		count, ok := countFormatArgs(format)
+		test.True(ok, format)
+		test.Equal(expected, count, format)
+	}
+
+	_, ok := countFormatArgs("%[2]s %[1]s")
+	test.False(ok)
+}
+
+func TestStrictFormatting_DescribesMismatch(t *testing.T) {
+	test := assert.New(t)
+
+	defer func() {
+		StrictFormatting = false
+	}()
+
+	StrictFormatting = true
+
+	test.EqualError(
+		Format(io.EOF, "host %s port %s", "example.com"),
+		output(
+			"host %s port %s",
+			"├─ EOF",
+			`└─ _format_error: format "host %s port %s" expects 2 arguments, got 1`,
+		),
+	)
+
+	test.EqualError(
+		Describe("host", "example.com").Format(nil, "port %d", 80),
+		output(
+			"port 80",
+			"└─ host: example.com",
+		),
+	)
+}
+
+func TestStrictFormatting_DisabledByDefault(t *testing.T) {
+	test := assert.New(t)
+
+	test.EqualError(
+		Format(nil, "host %s port %s", "example.com"),
+		"host example.com port %!s(MISSING)",
+	)
+}
@@ -0,0 +1,89 @@
+package karma
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Summarize returns one-line summary of given error: top-level message and
+// number of nested reasons. See Karma.Summarize() for details.
+func Summarize(err error, maxRunes int) string {
+	if err == nil {
+		return ""
+	}
+
+	if karma, ok := getKarma(err); ok {
+		return karma.Summarize(maxRunes)
+	}
+
+	message, _, _ := strings.Cut(err.Error(), "\n")
+
+	return summarize(message, countNestedReasons(err), maxRunes)
+}
+
+// Summarize returns one-line summary of message: top-level message followed
+// by " (... N more errors)" suffix, where N is number of nested errors at
+// all levels, levels which only carry context are not counted. If maxRunes is positive, message is truncated with "..." so
+// the summary is not longer than maxRunes runes.
+func (karma Karma) Summarize(maxRunes int) string {
+	return summarize(karma.GetMessage(), countNestedReasons(karma), maxRunes)
+}
+
+// countNestedReasons returns number of nested error levels, levels without
+// message, which only carry context, are not counted.
+func countNestedReasons(reason Reason) int {
+	count := 0
+
+	Walk(reason, func(depth int, reason Reason, _ *Context) {
+		if depth == 0 {
+			return
+		}
+
+		if karma, ok := getKarma(reason); ok && karma.Message == "" {
+			return
+		}
+
+		count++
+	})
+
+	return count
+}
+
+func summarize(message string, count int, maxRunes int) string {
+	suffix := ""
+	if count > 0 {
+		suffix = fmt.Sprintf(" (... %d more errors)", count)
+	}
+
+	if maxRunes <= 0 {
+		return message + suffix
+	}
+
+	runes := []rune(message)
+	suffixLength := len([]rune(suffix))
+
+	if len(runes)+suffixLength <= maxRunes {
+		return message + suffix
+	}
+
+	const ellipsis = "..."
+
+	length := maxRunes - suffixLength - len(ellipsis)
+	if length < 0 {
+		return truncateRunes([]rune(message+suffix), maxRunes)
+	}
+
+	return strings.TrimRight(string(runes[:length]), " ") + ellipsis + suffix
+}
+
+func truncateRunes(runes []rune, maxRunes int) string {
+	if len(runes) <= maxRunes {
+		return string(runes)
+	}
+
+	if maxRunes <= len("...") {
+		return string(runes[:maxRunes])
+	}
+
+	return string(runes[:maxRunes-len("...")]) + "..."
+}
@@ -0,0 +1,65 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize_AppendsNumberOfReasons(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		[]Reason{errors.New("first"), Format("deep", "second")},
+		"unable to connect",
+	)
+
+	test.Equal("unable to connect (... 3 more errors)", err.Summarize(0))
+	test.Equal("unable to connect (... 3 more errors)", Summarize(err, 80))
+	test.Equal(
+		"unable to connect",
+		Format(nil, "unable to connect").Summarize(0),
+	)
+}
+
+func TestSummarize_TruncatesMessage(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(errors.New("timeout"), "unable to connect")
+
+	test.Equal("unable... (... 1 more errors)", err.Summarize(30))
+	test.Equal("unable... (... 1 more errors)", err.Summarize(29))
+	test.Equal("unable...", err.Summarize(9))
+	test.Equal("un", err.Summarize(2))
+
+	test.Equal(
+		"unable to conn...",
+		Format(nil, "unable to connect to host").Summarize(17),
+	)
+}
+
+func TestSummarize_HandlesNonHierarchicalErrors(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal("", Summarize(nil, 10))
+	test.Equal("timeout", Summarize(errors.New("timeout\nat line 2"), 0))
+	test.Equal(
+		"a (... 2 more errors)",
+		Summarize(joinErrors(errors.New("a"), errors.New("b")), 0),
+	)
+}
+
+func TestSummarize_CountsOnlyErrorLevels(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		[]Reason{
+			Describe("attempt", 1).Reason(errors.New("timeout")),
+			Describe("attempt", 2).Reason(errors.New("refused")),
+		},
+		"unable to connect",
+	)
+
+	test.Equal("unable to connect (... 2 more errors)", err.Summarize(0))
+}
@@ -0,0 +1,58 @@
+package karma
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// TimedContext creates context with single pair with specified key, which
+// value is elapsed time since TimedContext was called up to the moment when
+// returned done function is called. Context can be used before done is
+// called, value is filled lazily. Only the first call of done has effect.
+func TimedContext(key string) (context *Context, done func()) {
+	value := &timedValue{}
+
+	start := time.Now()
+
+	var once sync.Once
+
+	done = func() {
+		once.Do(func() {
+			value.set(time.Since(start))
+		})
+	}
+
+	return Describe(key, value), done
+}
+
+// timedValue is a context value, which is filled by TimedContext() after
+// operation is done.
+type timedValue struct {
+	mutex    sync.RWMutex
+	duration string
+}
+
+func (value *timedValue) set(duration time.Duration) {
+	value.mutex.Lock()
+	defer value.mutex.Unlock()
+
+	value.duration = duration.String()
+}
+
+// String returns elapsed time or "<pending>" if operation is not done yet.
+func (value *timedValue) String() string {
+	value.mutex.RLock()
+	defer value.mutex.RUnlock()
+
+	if value.duration == "" {
+		return "<pending>"
+	}
+
+	return value.duration
+}
+
+// MarshalJSON marshals elapsed time as JSON string.
+func (value *timedValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(value.String())
+}
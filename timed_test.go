@@ -0,0 +1,56 @@
+package karma
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimedContext_FillsDurationLazily(t *testing.T) {
+	test := assert.New(t)
+
+	context, done := TimedContext("elapsed")
+
+	err := context.Format(errors.New("timeout"), "unable to connect")
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ timeout",
+			"└─ elapsed: <pending>",
+		),
+	)
+
+	time.Sleep(time.Millisecond)
+	done()
+
+	value, ok := err.GetContext().lookup("elapsed")
+	test.True(ok)
+
+	elapsed, parseErr := time.ParseDuration(fmt.Sprint(value))
+	test.NoError(parseErr)
+	test.GreaterOrEqual(elapsed, time.Millisecond)
+
+	test.True(strings.HasPrefix(JSON(err), `{"reason":"timeout"`))
+	test.Contains(JSON(err), `"value":"`+elapsed.String()+`"`)
+}
+
+func TestTimedContext_IgnoresRepeatedDone(t *testing.T) {
+	test := assert.New(t)
+
+	context, done := TimedContext("elapsed")
+
+	done()
+
+	first := ContextValueFormatter(context.Value)
+
+	time.Sleep(time.Millisecond)
+	done()
+
+	test.Equal(first, ContextValueFormatter(context.Value))
+}
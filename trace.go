@@ -0,0 +1,70 @@
+package karma
+
+import (
+	"strings"
+)
+
+// TraceKey is a context key which is used to store trace added by
+// FormatWithTrace(), WithTrace() and AppendTrace().
+const TraceKey = "_trace"
+
+// FormatWithTrace is the same as Format(), but also adds given trace to the
+// context of new message. Trace is a freeform string, which describes code
+// path which produced error. Empty trace is not added.
+func FormatWithTrace(
+	reason Reason,
+	message string,
+	trace string,
+	args ...interface{},
+) Karma {
+	var context *Context
+	if trace != "" {
+		context = Describe(TraceKey, trace)
+	}
+
+	return context.Format(reason, message, args...)
+}
+
+// WithTrace adds given trace to the context, see FormatWithTrace().
+func WithTrace(trace string) FormatOption {
+	return func(karma *Karma) {
+		*karma = karma.AppendTrace(trace)
+	}
+}
+
+// AppendTrace returns copy of message with given trace appended to its
+// trace on a new line. Empty trace is ignored.
+func (karma Karma) AppendTrace(trace string) Karma {
+	if trace == "" {
+		return karma
+	}
+
+	if value, ok := karma.Context.lookup(TraceKey); ok {
+		if previous, ok := value.(string); ok && previous != "" {
+			trace = previous + "\n" + trace
+		}
+
+		karma.Context = karma.Context.without(TraceKey)
+	}
+
+	karma.Context = karma.Context.Describe(TraceKey, trace)
+
+	return karma
+}
+
+// GetTrace returns traces of all levels of the hierarchy joined by
+// newlines, starting from top-level. Empty string is returned if there is
+// no trace.
+func GetTrace(err error) string {
+	traces := []string{}
+
+	Walk(err, func(_ int, _ Reason, context *Context) {
+		if value, ok := context.lookup(TraceKey); ok {
+			if trace, ok := value.(string); ok && trace != "" {
+				traces = append(traces, trace)
+			}
+		}
+	})
+
+	return strings.Join(traces, "\n")
+}
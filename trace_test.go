@@ -0,0 +1,55 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatWithTrace_AddsTraceToContext(t *testing.T) {
+	test := assert.New(t)
+
+	err := FormatWithTrace(
+		errors.New("timeout"),
+		"unable to connect to %s",
+		"dial.go:10",
+		"example.com",
+	)
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect to example.com",
+			"├─ timeout",
+			"└─ _trace: dial.go:10",
+		),
+	)
+	test.Equal("dial.go:10", GetTrace(err))
+
+	test.Equal(
+		Format(errors.New("timeout"), "unable to connect"),
+		FormatWithTrace(errors.New("timeout"), "unable to connect", ""),
+	)
+}
+
+func TestAppendTrace_AccumulatesTrace(t *testing.T) {
+	test := assert.New(t)
+
+	err := FormatWithTrace(nil, "unable to connect", "dial.go:10").
+		AppendTrace("").
+		AppendTrace("connect.go:20")
+
+	test.Equal("dial.go:10\nconnect.go:20", GetTrace(err))
+
+	err = FormatOptions(
+		err,
+		"unable to sync",
+		WithTrace("sync.go:30"),
+	)
+
+	test.Equal("sync.go:30\ndial.go:10\nconnect.go:20", GetTrace(err))
+
+	test.Equal("", GetTrace(errors.New("timeout")))
+	test.Equal("", GetTrace(Format(nil, "unable to connect").AppendTrace("")))
+}
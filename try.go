@@ -0,0 +1,25 @@
+package karma
+
+// TryN calls fn up to n times until it succeeds. If all attempts fail,
+// hierarchical message with all occurred errors as reasons is returned, each
+// reason is described with attempt number. If fn succeeds, nil is returned.
+//
+// Attempts are numbered from 1. If n is less than 1, fn is called once.
+func TryN(n int, message string, fn func(attempt int) error) error {
+	if n < 1 {
+		n = 1
+	}
+
+	reasons := []Reason{}
+
+	for attempt := 1; attempt <= n; attempt++ {
+		err := fn(attempt)
+		if err == nil {
+			return nil
+		}
+
+		reasons = append(reasons, Describe("attempt", attempt).Reason(err))
+	}
+
+	return Format(joinReasons(reasons), "%s", message)
+}
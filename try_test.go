@@ -0,0 +1,127 @@
+package karma
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryN_ReturnsNilOnSuccess(t *testing.T) {
+	test := assert.New(t)
+
+	calls := 0
+	err := TryN(3, "unable to connect", func(attempt int) error {
+		calls++
+		return nil
+	})
+
+	test.NoError(err)
+	test.Equal(1, calls)
+}
+
+func TestTryN_ReturnsNilOnPartialSuccess(t *testing.T) {
+	test := assert.New(t)
+
+	attempts := []int{}
+	err := TryN(3, "unable to connect", func(attempt int) error {
+		attempts = append(attempts, attempt)
+		if attempt < 2 {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	})
+
+	test.NoError(err)
+	test.Equal([]int{1, 2}, attempts)
+}
+
+func TestTryN_CollectsAllErrorsOnFailure(t *testing.T) {
+	test := assert.New(t)
+
+	err := TryN(3, "unable to connect", func(attempt int) error {
+		return fmt.Errorf("connection refused #%d", attempt)
+	})
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ connection refused #1",
+			"│  └─ attempt: 1",
+			"│",
+			"├─ connection refused #2",
+			"│  └─ attempt: 2",
+			"│",
+			"└─ connection refused #3",
+			"   └─ attempt: 3",
+		),
+	)
+}
+
+func TestTryN_FormatsSingleError(t *testing.T) {
+	test := assert.New(t)
+
+	err := TryN(1, "unable to connect", func(attempt int) error {
+		return errors.New("connection refused")
+	})
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"└─ connection refused",
+			"   └─ attempt: 1",
+		),
+	)
+}
+
+func TestTryN_CallsFnOnceIfNIsNotPositive(t *testing.T) {
+	test := assert.New(t)
+
+	for _, n := range []int{0, -1} {
+		calls := 0
+
+		err := TryN(n, "unable to connect", func(attempt int) error {
+			calls++
+			return errors.New("connection refused")
+		})
+
+		test.Equal(1, calls)
+		test.EqualError(
+			err,
+			output(
+				"unable to connect",
+				"└─ connection refused",
+				"   └─ attempt: 1",
+			),
+		)
+	}
+}
+
+func TestTryN_AddsDefaultContextToAllErrors(t *testing.T) {
+	test := assert.New(t)
+
+	SetDefaultContext(Describe("service", "api"))
+	defer ClearDefaultContext()
+
+	err := TryN(2, "unable to connect", func(attempt int) error {
+		return errors.New("connection refused")
+	})
+
+	test.EqualError(
+		err,
+		output(
+			"unable to connect",
+			"├─ connection refused",
+			"│  └─ attempt: 1",
+			"│",
+			"├─ connection refused",
+			"│  └─ attempt: 2",
+			"│",
+			"└─ service: api",
+		),
+	)
+}
@@ -0,0 +1,33 @@
+package karma
+
+// ContextKey represents typed context key, which guarantees that values
+// described with this key have the same type:
+//
+//	var HostKey = karma.ContextKey[string]{"host"}
+//
+//	HostKey.Describe("example.com").Format(err, "unable to connect")
+type ContextKey[T any] struct {
+	// Name is a key of context pair.
+	Name string
+}
+
+// Describe creates new context list with given value described with the key.
+func (key ContextKey[T]) Describe(value T) *Context {
+	return Describe(key.Name, value)
+}
+
+// GetContextValue returns value described with given typed key at any level
+// of the hierarchy, starting from top-level. False is returned if there is no
+// such value or it has different type, e.g. when error is restored from JSON.
+func GetContextValue[T any](err error, key ContextKey[T]) (T, bool) {
+	value, ok := findContextValue(err, key.Name)
+	if !ok {
+		var zero T
+
+		return zero, false
+	}
+
+	typed, ok := value.(T)
+
+	return typed, ok
+}
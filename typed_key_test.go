@@ -0,0 +1,45 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testHostKey = ContextKey[string]{"host"}
+	testPortKey = ContextKey[int]{"port"}
+)
+
+func TestContextKey_DescribesTypedValue(t *testing.T) {
+	test := assert.New(t)
+
+	err := Format(
+		testHostKey.Describe("example.com").
+			Describe("port", 80).
+			Format(errors.New("timeout"), "unable to connect"),
+		"unable to fetch",
+	)
+
+	host, ok := GetContextValue(err, testHostKey)
+	test.True(ok)
+	test.Equal("example.com", host)
+
+	port, ok := GetContextValue(err, testPortKey)
+	test.True(ok)
+	test.Equal(80, port)
+}
+
+func TestGetContextValue_ReturnsFalseOnTypeMismatch(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("port", "80").Format(nil, "unable to connect")
+
+	port, ok := GetContextValue(err, testPortKey)
+	test.False(ok)
+	test.Zero(port)
+
+	_, ok = GetContextValue(err, testHostKey)
+	test.False(ok)
+}
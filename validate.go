@@ -0,0 +1,73 @@
+package karma
+
+import (
+	"encoding/json"
+)
+
+// Validate checks all key-value pairs of context and returns the first
+// validation error: key is empty or value can't be marshaled to JSON.
+func (context *Context) Validate() error {
+	errs := context.validate(true)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs[0]
+}
+
+// ValidateAll checks all key-value pairs of context and returns all
+// validation errors. See Validate() for details.
+func (context *Context) ValidateAll() []error {
+	return context.validate(false)
+}
+
+func (context *Context) validate(first bool) []error {
+	var errs []error
+
+	index := 0
+	for node := context.resolve(); node != nil; node = node.Next {
+		if node.Key == "" && node.Value == nil {
+			continue
+		}
+
+		if node.Key == "" {
+			errs = append(
+				errs,
+				Format(nil, "context key at position %d is empty", index),
+			)
+		}
+
+		_, err := json.Marshal(node.Value)
+		if err != nil {
+			errs = append(
+				errs,
+				Describe("key", node.Key).Format(
+					err,
+					"context value is not JSON-serializable",
+				),
+			)
+		}
+
+		if first && len(errs) > 0 {
+			return errs[:1]
+		}
+
+		index++
+	}
+
+	return errs
+}
+
+// ValidateContext validates context at every level of hierarchy and returns
+// the first validation error. See Context.Validate() for details.
+func (karma Karma) ValidateContext() error {
+	var result error
+
+	walk(karma, 0, func(_ int, _ Reason, context *Context) bool {
+		result = context.Validate()
+
+		return result == nil
+	})
+
+	return result
+}
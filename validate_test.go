@@ -0,0 +1,69 @@
+package karma
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_Validate_ReturnsNilForValidContext(t *testing.T) {
+	test := assert.New(t)
+
+	var context *Context
+
+	test.NoError(context.Validate())
+	test.NoError(
+		Describe("host", "example.com").Describe("port", 80).Validate(),
+	)
+	test.Empty(Describe("host", "example.com").ValidateAll())
+}
+
+func TestContext_Validate_ReturnsFirstError(t *testing.T) {
+	test := assert.New(t)
+
+	context := Describe("host", "example.com").
+		Describe("", "empty").
+		Describe("channel", make(chan int))
+
+	test.EqualError(
+		context.Validate(),
+		"context key at position 1 is empty",
+	)
+
+	errs := context.ValidateAll()
+	test.Len(errs, 2)
+	test.EqualError(
+		errs[1],
+		output(
+			"context value is not JSON-serializable",
+			"├─ json: unsupported type: chan int",
+			"└─ key: channel",
+		),
+	)
+}
+
+func TestKarma_ValidateContext_ChecksEveryLevel(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		Describe("callback", func() {}).Format(
+			errors.New("timeout"),
+			"unable to connect",
+		),
+		"unable to fetch",
+	)
+
+	test.EqualError(
+		err.ValidateContext(),
+		output(
+			"context value is not JSON-serializable",
+			"├─ json: unsupported type: func()",
+			"└─ key: callback",
+		),
+	)
+
+	test.NoError(
+		Describe("host", "example.com").Reason("valid").ValidateContext(),
+	)
+}
@@ -0,0 +1,48 @@
+package karma
+
+import (
+	"sync/atomic"
+)
+
+// AppVersionKey is a context key which is used to store application version.
+// It is marshaled to JSON as top-level "app_version" field.
+const AppVersionKey = "_app_version"
+
+var applicationVersion atomic.Value
+
+// SetApplicationVersion sets application version, which will be added to
+// messages by WithVersion().
+func SetApplicationVersion(version string) {
+	applicationVersion.Store(version)
+}
+
+func getApplicationVersion() string {
+	version, _ := applicationVersion.Load().(string)
+
+	return version
+}
+
+// WithVersion returns copy of given message with application version set by
+// SetApplicationVersion() added to its context. If version is not set,
+// message is returned as is.
+func WithVersion(err Karma) Karma {
+	version := getApplicationVersion()
+	if version == "" {
+		return err
+	}
+
+	return err.Annotate(AppVersionKey, version)
+}
+
+// GetVersion returns application version previously associated with error
+// using WithVersion().
+func GetVersion(err error) (string, bool) {
+	value, ok := findContextValue(err, AppVersionKey)
+	if !ok {
+		return "", false
+	}
+
+	version, ok := value.(string)
+
+	return version, ok
+}
@@ -0,0 +1,62 @@
+package karma
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithVersion_AddsVersionToContext(t *testing.T) {
+	test := assert.New(t)
+
+	SetApplicationVersion("1.2.3")
+	defer SetApplicationVersion("")
+
+	err := WithVersion(Describe("host", "example.com").Format(nil, "failure"))
+
+	test.EqualError(
+		err,
+		output(
+			"failure",
+			"├─ host: example.com",
+			"└─ _app_version: 1.2.3",
+		),
+	)
+
+	version, ok := GetVersion(Format(err, "top"))
+	test.True(ok)
+	test.Equal("1.2.3", version)
+}
+
+func TestWithVersion_KeepsMessageWithoutVersion(t *testing.T) {
+	test := assert.New(t)
+
+	err := WithVersion(Format(nil, "failure"))
+
+	test.Nil(err.GetContext())
+
+	_, ok := GetVersion(err)
+	test.False(ok)
+}
+
+func TestWithVersion_MarshalsVersionAtTopLevel(t *testing.T) {
+	test := assert.New(t)
+
+	SetApplicationVersion("1.2.3")
+	defer SetApplicationVersion("")
+
+	err := WithVersion(Describe("host", "example.com").Format(nil, "failure"))
+
+	data, marshalErr := json.Marshal(err)
+	test.NoError(marshalErr)
+	test.JSONEq(
+		`{"reason":null,"message":"failure","app_version":"1.2.3",`+
+			`"context":[{"key":"host","value":"example.com"}]}`,
+		string(data),
+	)
+
+	var restored Karma
+	test.NoError(json.Unmarshal(data, &restored))
+	test.Equal(err, restored)
+}
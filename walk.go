@@ -0,0 +1,101 @@
+package karma
+
+// Walk calls fn for every node of given reason tree, starting from reason
+// itself at depth 0. Any reason is supported: Karma, errors implementing
+// Hierarchical interface, errors created by errors.Join() and plain errors or
+// strings, which are leaves of the tree. Context is passed only for nodes
+// which have one, otherwise it is nil.
+func Walk(reason Reason, fn func(depth int, reason Reason, context *Context)) {
+	walk(reason, 0, func(depth int, reason Reason, context *Context) bool {
+		fn(depth, reason, context)
+
+		return true
+	})
+}
+
+// walk traverses reason tree in depth-first order and stops as soon as fn
+// returns false. Returns false if traversal was stopped.
+func walk(
+	reason Reason,
+	depth int,
+	fn func(depth int, reason Reason, context *Context) bool,
+) bool {
+	return walkPath(
+		reason,
+		nil,
+		func(path []int, reason Reason, context *Context) bool {
+			return fn(depth+len(path), reason, context)
+		},
+	)
+}
+
+// walkPath is the same as walk(), but passes path of reason indices from
+// the root to every node instead of depth.
+func walkPath(
+	reason Reason,
+	path []int,
+	fn func(path []int, reason Reason, context *Context) bool,
+) bool {
+	if reason == nil {
+		return true
+	}
+
+	var (
+		context *Context
+		nested  []Reason
+	)
+
+	if karma, ok := getKarma(reason); ok {
+		context = karma.Context
+		nested = karma.GetReasons()
+	} else if _, ok := reason.(*Karma); ok {
+		return true
+	} else {
+		if flat, ok := reason.(*flattened); ok {
+			context = flat.context
+		}
+
+		switch typed := reason.(type) {
+		case joinedError:
+			for _, err := range typed.Unwrap() {
+				if err != nil {
+					nested = append(nested, err)
+				}
+			}
+		case Hierarchical:
+			nested = typed.GetReasons()
+		}
+	}
+
+	if !fn(path, reason, context) {
+		return false
+	}
+
+	for index, reason := range nested {
+		if !walkPath(reason, append(path[:len(path):len(path)], index), fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Reduce folds whole reason tree of given error into single value. Nodes are
+// visited in the same order as in Walk().
+func Reduce[T any](
+	err error,
+	initial T,
+	fn func(acc T, reason Reason, depth int, context *Context) T,
+) T {
+	acc := initial
+
+	if err == nil {
+		return acc
+	}
+
+	Walk(err, func(depth int, reason Reason, context *Context) {
+		acc = fn(acc, reason, depth, context)
+	})
+
+	return acc
+}
@@ -0,0 +1,118 @@
+package karma
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func walkedNodes(reason Reason) []string {
+	nodes := []string{}
+
+	Walk(reason, func(depth int, reason Reason, context *Context) {
+		message := fmt.Sprint(reason)
+		if karma, ok := reason.(Karma); ok {
+			message = karma.GetMessage()
+		}
+
+		nodes = append(
+			nodes,
+			fmt.Sprintf(
+				"%s%s %v",
+				strings.Repeat(" ", depth),
+				message,
+				context.GetKeyValuePairs(),
+			),
+		)
+	})
+
+	return nodes
+}
+
+func TestWalk_VisitsEveryNodeWithDepth(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Format(
+		[]Reason{
+			errors.New("first"),
+			Describe("port", 80).Format(errors.New("deep"), "second"),
+		},
+		"top",
+	)
+
+	test.Equal(
+		[]string{
+			"top [host example.com]",
+			" first []",
+			" second [port 80]",
+			"  deep []",
+		},
+		walkedNodes(err),
+	)
+}
+
+func TestWalk_TraversesExternalAndJoinedErrors(t *testing.T) {
+	test := assert.New(t)
+
+	err := codedError{
+		message: "external",
+		reasons: []Reason{
+			joinErrors(errors.New("a"), Format("b", "wrapped")),
+		},
+	}
+
+	test.Equal(
+		[]string{
+			"external []",
+			" a\nwrapped\n└─ b []",
+			"  a []",
+			"  wrapped []",
+			"   b []",
+		},
+		walkedNodes(err),
+	)
+}
+
+func TestWalk_VisitsPlainReason(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal([]string{"plain []"}, walkedNodes("plain"))
+	test.Empty(walkedNodes(nil))
+}
+
+func TestReduce_CountsContextPairs(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Describe("port", 80).Format(
+		Describe("user", "root").Format(errors.New("denied"), "auth failed"),
+		"unable to connect",
+	)
+
+	count := Reduce(
+		err,
+		0,
+		func(acc int, _ Reason, _ int, context *Context) int {
+			return acc + len(context.GetKeyValues())
+		},
+	)
+
+	test.Equal(3, count)
+}
+
+func TestReduce_ReturnsInitialForNilError(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(
+		"initial",
+		Reduce(
+			nil,
+			"initial",
+			func(acc string, _ Reason, _ int, _ *Context) string {
+				return acc + "!"
+			},
+		),
+	)
+}
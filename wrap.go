@@ -0,0 +1,45 @@
+package karma
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WrapTyped creates new hierarchical message same as Format() and returns
+// it as the same type as the reason has. T is expected to be an interface
+// type implemented by Karma, e.g. error, otherwise WrapTyped panics.
+func WrapTyped[T error](reason T, message string, args ...interface{}) T {
+	var result interface{} = Format(reason, message, args...)
+
+	typed, ok := result.(T)
+	if !ok {
+		panic(fmt.Sprintf(
+			"karma: unable to wrap reason as %s: karma.Karma does not implement it",
+			reflect.TypeOf((*T)(nil)).Elem(),
+		))
+	}
+
+	return typed
+}
+
+// WrapCompat creates new hierarchical message with err as reason, which is
+// returned by errors.Unwrap() as is, like error created by fmt.Errorf() with
+// %w verb. Unlike Format(), message is not treated as format string and err
+// wrapping multiple errors, like errors.Join() result, is not split into
+// separate branches.
+func WrapCompat(err error, message string) Karma {
+	var reason Reason
+	if err != nil {
+		reason = err
+	}
+
+	return newKarma(
+		nil,
+		reason,
+		message,
+		GetDefaultContext(nil),
+		func(karma *Karma) {
+			karma.Reason = reason
+		},
+	)
+}
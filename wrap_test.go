@@ -0,0 +1,84 @@
+package karma
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapTyped_ReturnsSameInterfaceType(t *testing.T) {
+	test := assert.New(t)
+
+	var reason error = errors.New("connection refused")
+
+	var wrapped error = WrapTyped(reason, "unable to connect to %s", "db")
+
+	test.EqualError(
+		wrapped,
+		output(
+			"unable to connect to db",
+			"└─ connection refused",
+		),
+	)
+
+	var hierarchical interface {
+		error
+		GetMessage() string
+	} = Format(nil, "reason")
+
+	test.Equal("wrapper", WrapTyped(hierarchical, "wrapper").GetMessage())
+}
+
+func TestWrapTyped_PanicsIfTypeIsNotImplemented(t *testing.T) {
+	test := assert.New(t)
+
+	test.PanicsWithValue(
+		"karma: unable to wrap reason as karma.customSimpleError: "+
+			"karma.Karma does not implement it",
+		func() {
+			WrapTyped(customSimpleError{"custom"}, "unable to wrap")
+		},
+	)
+}
+
+func TestWrapCompat_IsTransparentForErrorsPackage(t *testing.T) {
+	test := assert.New(t)
+
+	reason := joinErrors(io.EOF, os.ErrNotExist)
+
+	err := WrapCompat(reason, "100% failed")
+
+	test.Equal(reason, errors.Unwrap(err))
+	test.True(errors.Is(err, io.EOF))
+	test.True(errors.Is(err, os.ErrNotExist))
+	test.Equal("100% failed", err.GetMessage())
+
+	test.Nil(errors.Unwrap(WrapCompat(nil, "no reason")))
+}
+
+func TestKarma_Unwrap_ReturnsSingleErrorReason(t *testing.T) {
+	test := assert.New(t)
+
+	test.Equal(io.EOF, errors.Unwrap(Format(io.EOF, "unable to read")))
+}
+
+func TestWrapCompat_KeepsFmtWrappedErrorAsIs(t *testing.T) {
+	test := assert.New(t)
+
+	reason := fmt.Errorf("a: %w", io.EOF)
+
+	err := WrapCompat(reason, "b")
+
+	test.EqualError(
+		err,
+		output(
+			"b",
+			"└─ a: EOF",
+		),
+	)
+	test.Equal(reason, errors.Unwrap(err))
+}
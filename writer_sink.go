@@ -0,0 +1,119 @@
+package karma
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// WriterSinkText is a format of WriterSink, which writes errors as
+	// hierarchical text.
+	WriterSinkText = "text"
+
+	// WriterSinkJSON is a format of WriterSink, which writes errors as JSON
+	// lines.
+	WriterSinkJSON = "json"
+
+	// WriterSinkLogfmt is a format of WriterSink, which writes errors as
+	// logfmt lines.
+	WriterSinkLogfmt = "logfmt"
+)
+
+// WriterSink writes captured errors to io.Writer in specified format. It is
+// safe for concurrent use.
+type WriterSink struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	format string
+}
+
+// NewWriterSink creates new WriterSink, which writes errors to w in given
+// format: "text", "json" or "logfmt". Unknown format is treated as "text".
+func NewWriterSink(w io.Writer, format string) *WriterSink {
+	return &WriterSink{
+		writer: w,
+		format: format,
+	}
+}
+
+// SetWriterSink sets writer sink, which will write every message created by
+// Format() and Context.Format(). It replaces sink set by SetSink(). Pass nil
+// to stop writing.
+func SetWriterSink(sink *WriterSink) {
+	if sink == nil {
+		globalSink.Store(nil)
+		return
+	}
+
+	globalSink.Store(&globalCapturer{sink})
+}
+
+// Capture writes given error to the underlying writer followed by newline.
+// Nil errors are ignored, write errors are ignored as well.
+func (sink *WriterSink) Capture(err error) {
+	if err == nil {
+		return
+	}
+
+	// lazy pairs of message which is being created are not evaluated, see
+	// FormatLazy()
+	if karma, ok := err.(Karma); ok {
+		karma.Context = karma.Context.withoutLazy()
+		err = karma
+	}
+
+	var line string
+
+	switch sink.format {
+	case WriterSinkJSON:
+		line = JSON(err)
+	case WriterSinkLogfmt:
+		line = logfmt(err)
+	default:
+		line = err.Error()
+	}
+
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	_, _ = io.WriteString(sink.writer, line+"\n")
+}
+
+// logfmt returns logfmt representation of given error: colon-joined messages
+// of all levels as "message" field followed by context pairs of all levels.
+func logfmt(err error) string {
+	fields := []string{
+		"message=" + logfmtValue(strings.Join(GetMessages(err), ": ")),
+	}
+
+	Walk(err, func(_ int, _ Reason, context *Context) {
+		context.Walk(func(key string, value interface{}) {
+			fields = append(
+				fields,
+				logfmtKey(key)+"="+logfmtValue(formatContextValue(key, value)),
+			)
+		})
+	})
+
+	return strings.Join(fields, " ")
+}
+
+func logfmtKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+
+		return r
+	}, key)
+}
+
+func logfmtValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " =\"\\\n\t") {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
@@ -0,0 +1,51 @@
+package karma
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriterSink_WritesErrorsInFormat(t *testing.T) {
+	test := assert.New(t)
+
+	err := Describe("host", "example.com").Describe("note", "a b").Format(
+		errors.New("timeout"),
+		"unable to connect",
+	)
+
+	var buffer bytes.Buffer
+
+	NewWriterSink(&buffer, WriterSinkText).Capture(err)
+	test.Equal(err.Error()+"\n", buffer.String())
+
+	buffer.Reset()
+	NewWriterSink(&buffer, WriterSinkJSON).Capture(err)
+	test.Equal(JSON(err)+"\n", buffer.String())
+
+	buffer.Reset()
+	NewWriterSink(&buffer, WriterSinkLogfmt).Capture(err)
+	test.Equal(
+		`message="unable to connect: timeout" host=example.com note="a b"`+"\n",
+		buffer.String(),
+	)
+
+	buffer.Reset()
+	NewWriterSink(&buffer, WriterSinkLogfmt).Capture(nil)
+	test.Empty(buffer.String())
+}
+
+func TestSetWriterSink_WritesFormattedErrors(t *testing.T) {
+	test := assert.New(t)
+
+	var buffer bytes.Buffer
+
+	SetWriterSink(NewWriterSink(&buffer, WriterSinkLogfmt))
+	defer SetWriterSink(nil)
+
+	Describe("user", "root").Format(nil, "access denied")
+
+	test.Equal("message=\"access denied\" user=root\n", buffer.String())
+}